@@ -2,23 +2,72 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
 	"github.com/dedinirtadinata/docxtool/docgenpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"log"
 	"os"
 )
 
+// envOr returns the environment variable key, or def when it is unset.
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
+
 func main() {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	addr := flag.String("addr", envOr("DOCGEN_ADDR", "localhost:5051"), "server address (env DOCGEN_ADDR)")
+	apiKey := flag.String("api-key", envOr("DOCGEN_API_KEY", ""), "API key; leave empty to authenticate with -cert only (env DOCGEN_API_KEY)")
+	useTLS := flag.Bool("tls", false, "connect with TLS (implied by -ca and -cert)")
+	caFile := flag.String("ca", os.Getenv("DOCGEN_TLS_CA"), "CA bundle for the server certificate, default system roots (env DOCGEN_TLS_CA)")
+	certFile := flag.String("cert", os.Getenv("DOCGEN_TLS_CERT"), "client certificate for mutual TLS (env DOCGEN_TLS_CERT)")
+	keyFile := flag.String("key", os.Getenv("DOCGEN_TLS_KEY"), "client private key (env DOCGEN_TLS_KEY)")
+	serverName := flag.String("server-name", "", "expected server name, default the host of -addr")
+	flag.Parse()
+	if *apiKey == "" && *certFile == "" {
+		log.Fatal("no credentials: set -api-key (or DOCGEN_API_KEY) or -cert and -key for mutual TLS")
+	}
+
+	creds := insecure.NewCredentials()
+	if *useTLS || *caFile != "" || *certFile != "" {
+		tc := &tls.Config{ServerName: *serverName, MinVersion: tls.VersionTLS12}
+		if *caFile != "" {
+			pem, err := os.ReadFile(*caFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			tc.RootCAs = x509.NewCertPool()
+			if !tc.RootCAs.AppendCertsFromPEM(pem) {
+				log.Fatalf("no certificates in %s", *caFile)
+			}
+		}
+		if *certFile != "" {
+			cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			tc.Certificates = []tls.Certificate{cert}
+		}
+		creds = credentials.NewTLS(tc)
+	}
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer conn.Close()
 
-	//untuk auth key
-	md := metadata.Pairs("x-api-key", "secret-key-1")
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	//untuk auth key; dengan sertifikat klien boleh kosong
+	ctx := context.Background()
+	if *apiKey != "" {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("x-api-key", *apiKey))
+	}
 
 	c := docgenpb.NewDocServiceClient(conn)
 
@@ -0,0 +1,464 @@
+// Package config holds the server configuration. Values come from, later
+// sources overriding earlier ones:
+//
+//  1. built-in defaults (Default)
+//  2. a YAML file given by --config or DOCGEN_CONFIG
+//  3. environment variables (DOCGEN_*, see the env tags below)
+//  4. command-line flags
+//
+// Every field has a YAML key; env and flag tags mark the fields that can
+// also be set from the environment or the command line.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Listen    Listen    `yaml:"listen"`
+	TLS       TLS       `yaml:"tls"`
+	Limits    Limits    `yaml:"limits"`
+	Converter Converter `yaml:"converter"`
+	Auth      Auth      `yaml:"auth"`
+	Logging   Logging   `yaml:"logging"`
+	Storage   Storage   `yaml:"storage"`
+	Documents Documents `yaml:"documents"`
+	// Tenants are keyed by the tenant name of API keys, JWTs and client
+	// certificates. When set, credentials of other tenants are rejected.
+	Tenants map[string]Tenant `yaml:"tenants"`
+}
+
+type Listen struct {
+	GRPC    string `yaml:"grpc" env:"DOCGEN_GRPC_ADDR" flag:"grpc-addr" usage:"gRPC listen address"`
+	HTTP    string `yaml:"http" env:"DOCGEN_HTTP_ADDR" flag:"http-addr" usage:"HTTP gateway listen address, empty disables it"`
+	Metrics string `yaml:"metrics" env:"DOCGEN_METRICS_ADDR" flag:"metrics-addr" usage:"Prometheus /metrics listen address, empty disables it"`
+}
+
+// TLS is off unless both files are set; it then covers gRPC and the HTTP
+// gateway (metrics stay plain HTTP). The files are reloaded when they change.
+type TLS struct {
+	CertFile string `yaml:"cert_file" env:"DOCGEN_TLS_CERT" flag:"tls-cert" usage:"server certificate chain (PEM)"`
+	KeyFile  string `yaml:"key_file" env:"DOCGEN_TLS_KEY" flag:"tls-key" usage:"server private key (PEM)"`
+	// ClientAuth is none, optional (verify a certificate if one is sent) or
+	// require (mutual TLS).
+	ClientAuth     string        `yaml:"client_auth" env:"DOCGEN_TLS_CLIENT_AUTH" flag:"tls-client-auth" usage:"client certificates: none, optional or require"`
+	ClientCAFile   string        `yaml:"client_ca_file" env:"DOCGEN_TLS_CLIENT_CA" flag:"tls-client-ca" usage:"CA bundle client certificates are verified against (PEM)"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"DOCGEN_TLS_RELOAD_INTERVAL" usage:"how often certificate files are checked for changes, 0 disables"`
+	// ClientCerts maps client certificates to callers; empty accepts every
+	// verified certificate as a caller without scopes.
+	ClientCerts []ClientCert `yaml:"client_certs"`
+}
+
+// ClientCert grants scopes to the client certificate with the name Match:
+// "uri:spiffe://...", "dns:host", "email:addr" or "cn:common name".
+type ClientCert struct {
+	Match  string   `yaml:"match"`
+	Name   string   `yaml:"name"`
+	Tenant string   `yaml:"tenant"`
+	Scopes []string `yaml:"scopes"`
+}
+
+// Enabled reports whether a certificate is configured.
+func (t TLS) Enabled() bool { return t.CertFile != "" || t.KeyFile != "" }
+
+// MutualAuth reports whether client certificates are verified.
+func (t TLS) MutualAuth() bool { return t.ClientAuth == "optional" || t.ClientAuth == "require" }
+
+// Limits apply to every client separately; a client is a caller, a tenant or
+// an IP address depending on RateKey.
+type Limits struct {
+	RateLimit        float64 `yaml:"rate_limit" env:"DOCGEN_RATE_LIMIT" flag:"rate-limit" usage:"requests per second per client, 0 disables the limit"`
+	RateBurst        int     `yaml:"rate_burst" env:"DOCGEN_RATE_BURST" flag:"rate-burst" usage:"rate limit burst"`
+	RateKey          string  `yaml:"rate_key" env:"DOCGEN_RATE_KEY" flag:"rate-key" usage:"what a client is for limits and quotas: caller, tenant or ip"`
+	DailyDocuments   int     `yaml:"daily_documents" env:"DOCGEN_DAILY_DOCUMENTS" usage:"documents per client per UTC day, 0 is unlimited"`
+	MonthlyDocuments int     `yaml:"monthly_documents" env:"DOCGEN_MONTHLY_DOCUMENTS" usage:"documents per client per UTC month, 0 is unlimited"`
+	// Clients overrides the limits above for single clients, keyed like
+	// "key:<api key id>", "jwt:<sub>", "cert:<name>", "tenant:<name>" or
+	// "ip:<address>"; fields left out keep the default.
+	Clients        map[string]ClientLimits `yaml:"clients"`
+	MaxMessageSize ByteSize                `yaml:"max_message_size" env:"DOCGEN_MAX_MESSAGE_SIZE" usage:"largest gRPC request and HTTP gateway body"`
+	Docx           Docx                    `yaml:"docx"`
+}
+
+// ClientLimits overrides Limits for one client.
+type ClientLimits struct {
+	RateLimit        *float64 `yaml:"rate_limit,omitempty"`
+	RateBurst        *int     `yaml:"rate_burst,omitempty"`
+	DailyDocuments   *int     `yaml:"daily_documents,omitempty"`
+	MonthlyDocuments *int     `yaml:"monthly_documents,omitempty"`
+}
+
+// Docx mirrors service.DocxLimits; zero disables a limit.
+type Docx struct {
+	MaxCompressedSize   ByteSize `yaml:"max_compressed_size" env:"DOCGEN_DOCX_MAX_SIZE" usage:"largest uploaded DOCX"`
+	MaxUncompressedSize ByteSize `yaml:"max_uncompressed_size" env:"DOCGEN_DOCX_MAX_UNCOMPRESSED_SIZE" usage:"largest DOCX after decompression"`
+	MaxEntries          int      `yaml:"max_entries" env:"DOCGEN_DOCX_MAX_ENTRIES" usage:"most zip entries in a DOCX"`
+	MaxCompressionRatio float64  `yaml:"max_compression_ratio" env:"DOCGEN_DOCX_MAX_COMPRESSION_RATIO" usage:"highest compression ratio of a zip entry over 1 MiB"`
+	MaxXMLDepth         int      `yaml:"max_xml_depth" env:"DOCGEN_DOCX_MAX_XML_DEPTH" usage:"deepest XML element nesting"`
+}
+
+type Converter struct {
+	Workers int     `yaml:"workers" env:"DOCGEN_WORKERS" flag:"workers" usage:"concurrent LibreOffice conversions"`
+	Soffice string  `yaml:"soffice" env:"DOCGEN_SOFFICE" flag:"soffice" usage:"path to soffice, empty searches the usual locations"`
+	Sandbox Sandbox `yaml:"sandbox"`
+}
+
+// Sandbox mirrors service.SandboxConfig; zero disables a limit.
+type Sandbox struct {
+	CPUTime      time.Duration `yaml:"cpu_time" env:"DOCGEN_SANDBOX_CPU_TIME" usage:"CPU time per conversion"`
+	WallTime     time.Duration `yaml:"wall_time" env:"DOCGEN_SANDBOX_WALL_TIME" usage:"wall time per conversion"`
+	AddressSpace ByteSize      `yaml:"address_space" env:"DOCGEN_SANDBOX_ADDRESS_SPACE" usage:"address space of soffice"`
+	OpenFiles    uint64        `yaml:"open_files" env:"DOCGEN_SANDBOX_OPEN_FILES" usage:"open files of soffice"`
+	FileSize     ByteSize      `yaml:"file_size" env:"DOCGEN_SANDBOX_FILE_SIZE" usage:"largest file soffice may write"`
+	Namespaces   bool          `yaml:"namespaces" env:"DOCGEN_SANDBOX_NAMESPACES" usage:"run soffice in Linux namespaces (no network, private /tmp) when available"`
+}
+
+type Auth struct {
+	// tidak ada flag: key di command line kelihatan di ps
+	APIKeys []string `yaml:"api_keys" env:"DOCGEN_API_KEYS" usage:"API keys with every scope, comma-separated"`
+	// KeysFile holds hashed keys managed by the KeyAdmin RPCs.
+	KeysFile      string        `yaml:"keys_file" env:"DOCGEN_KEYS_FILE" flag:"keys-file" usage:"API key store (JSON), reloaded on SIGHUP and on change"`
+	WatchInterval time.Duration `yaml:"watch_interval" env:"DOCGEN_KEYS_WATCH_INTERVAL" usage:"how often keys_file is checked for changes, 0 disables"`
+	JWT           JWT           `yaml:"jwt"`
+	// MethodScopes is the scope each RPC needs ("/docgen.KeyAdmin/*" covers
+	// a whole service, "" any authenticated caller). Entries in the config
+	// file are merged over the defaults; methods not listed are denied.
+	MethodScopes map[string]string `yaml:"method_scopes"`
+}
+
+// JWT enables bearer tokens when a secret or a JWKS is set.
+type JWT struct {
+	Issuer      string        `yaml:"issuer" env:"DOCGEN_JWT_ISSUER" usage:"required iss claim"`
+	Audience    []string      `yaml:"audience" env:"DOCGEN_JWT_AUDIENCE" usage:"accepted aud values, comma-separated"`
+	Leeway      time.Duration `yaml:"leeway" env:"DOCGEN_JWT_LEEWAY" usage:"clock skew allowed for exp and nbf"`
+	HS256Secret string        `yaml:"hs256_secret" env:"DOCGEN_JWT_HS256_SECRET" usage:"shared secret for HS256 tokens"`
+	JWKSFile    string        `yaml:"jwks_file" env:"DOCGEN_JWT_JWKS_FILE" usage:"JWK set for RS256/ES256 tokens"`
+	JWKSURL     string        `yaml:"jwks_url" env:"DOCGEN_JWT_JWKS_URL" usage:"JWK set URL, e.g. the OIDC jwks_uri"`
+	JWKSRefresh time.Duration `yaml:"jwks_refresh" env:"DOCGEN_JWT_JWKS_REFRESH" usage:"how long a fetched JWK set is cached"`
+	Claims      JWTClaims     `yaml:"claims"`
+}
+
+// Enabled reports whether bearer tokens are verified as JWTs.
+func (j JWT) Enabled() bool { return j.HS256Secret != "" || j.JWKSFile != "" || j.JWKSURL != "" }
+
+// JWTClaims names the claims mapped to the caller.
+type JWTClaims struct {
+	Subject string `yaml:"subject" env:"DOCGEN_JWT_SUBJECT_CLAIM" usage:"claim holding the caller identity"`
+	Scopes  string `yaml:"scopes" env:"DOCGEN_JWT_SCOPES_CLAIM" usage:"claim holding the scopes (string or array)"`
+	Tenant  string `yaml:"tenant" env:"DOCGEN_JWT_TENANT_CLAIM" usage:"claim holding the tenant"`
+	Name    string `yaml:"name" env:"DOCGEN_JWT_NAME_CLAIM" usage:"claim holding a display name"`
+}
+
+type Logging struct {
+	Level  string `yaml:"level" env:"DOCGEN_LOG_LEVEL" flag:"log-level" usage:"debug, info, warn or error"`
+	Format string `yaml:"format" env:"DOCGEN_LOG_FORMAT" flag:"log-format" usage:"json or console"`
+}
+
+type Storage struct {
+	AuditFile      string        `yaml:"audit_file" env:"DOCGEN_AUDIT_FILE" usage:"audit trail (JSON lines)"`
+	AuditData      bool          `yaml:"audit_data" env:"DOCGEN_AUDIT_DATA" usage:"record full request data in the audit trail"`
+	AuditRedact    []string      `yaml:"audit_redact" env:"DOCGEN_AUDIT_REDACT" usage:"data keys masked in the audit trail, comma-separated"`
+	RegistryFile   string        `yaml:"registry_file" env:"DOCGEN_REGISTRY_FILE" usage:"document registry for VerifyPDF, empty keeps it in memory"`
+	CacheDir       string        `yaml:"cache_dir" env:"DOCGEN_CACHE_DIR" usage:"result cache directory, empty caches in memory"`
+	CacheSize      ByteSize      `yaml:"cache_size" env:"DOCGEN_CACHE_SIZE" usage:"result cache size, 0 disables the cache"`
+	CacheTTL       time.Duration `yaml:"cache_ttl" env:"DOCGEN_CACHE_TTL" usage:"how long cached results are served"`
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" env:"DOCGEN_IDEMPOTENCY_TTL" usage:"how long idempotency keys are remembered"`
+	// IdempotencySize bounds the memory used for remembered responses; the
+	// oldest keys are forgotten first.
+	IdempotencySize ByteSize `yaml:"idempotency_size" env:"DOCGEN_IDEMPOTENCY_SIZE" usage:"memory for responses of idempotent calls"`
+	UsageFile       string   `yaml:"usage_file" env:"DOCGEN_USAGE_FILE" usage:"document quota counters (JSON), empty keeps them in memory"`
+}
+
+type Documents struct {
+	SignersFile    string `yaml:"signers_file" env:"DOCGEN_SIGNERS_FILE" usage:"signing profiles (JSON)"`
+	TrustRoots     string `yaml:"trust_roots" env:"DOCGEN_TRUST_ROOTS" usage:"CA certificates (PEM) trusted by VerifyPDF"`
+	VerifyURL      string `yaml:"verify_url" env:"DOCGEN_VERIFY_URL" usage:"public verification page for QR stamps, {code} is replaced"`
+	SanitizePolicy string `yaml:"sanitize_policy" env:"DOCGEN_SANITIZE_POLICY" usage:"active content in templates: strip or reject"`
+}
+
+// Tenant holds the defaults of one tenant.
+type Tenant struct {
+	// Signer is the signing profile used when a request names none.
+	Signer string `yaml:"signer"`
+	// FontDirs are extra font directories for this tenant's conversions.
+	FontDirs  []string   `yaml:"font_dirs"`
+	Watermark *Watermark `yaml:"watermark"`
+}
+
+// Watermark mirrors docgenpb.Watermark; it is applied to generate calls
+// that do not ask for one.
+type Watermark struct {
+	Text      string   `yaml:"text"`
+	ImageFile string   `yaml:"image_file"`
+	FontSize  float64  `yaml:"font_size"`
+	Color     string   `yaml:"color"`
+	Opacity   float64  `yaml:"opacity"`
+	Rotation  *float64 `yaml:"rotation,omitempty"`
+}
+
+// Default returns the values used when nothing else is configured. There are
+// no default API keys.
+func Default() *Config {
+	return &Config{
+		Listen: Listen{GRPC: ":5051", HTTP: ":8080", Metrics: ":9090"},
+		TLS:    TLS{ClientAuth: "none", ReloadInterval: time.Minute},
+		Limits: Limits{
+			RateLimit:      2,
+			RateBurst:      5,
+			RateKey:        "caller",
+			MaxMessageSize: 64 << 20,
+			Docx: Docx{
+				MaxCompressedSize:   32 << 20,
+				MaxUncompressedSize: 256 << 20,
+				MaxEntries:          2000,
+				MaxCompressionRatio: 100,
+				MaxXMLDepth:         256,
+			},
+		},
+		Converter: Converter{
+			Workers: 5,
+			Sandbox: Sandbox{
+				CPUTime:      2 * time.Minute,
+				WallTime:     5 * time.Minute,
+				AddressSpace: 4 << 30,
+				OpenFiles:    1024,
+				FileSize:     512 << 20,
+				Namespaces:   true,
+			},
+		},
+		Auth: Auth{
+			WatchInterval: 5 * time.Second,
+			MethodScopes: map[string]string{
+				"/docgen.DocService/GetPlaceholders":  "templates:read",
+				"/docgen.DocService/ValidateTemplate": "templates:read",
+				"/docgen.DocService/RenderPreview":    "generate:preview",
+				"/docgen.DocService/GeneratePDF":      "generate:pdf",
+				"/docgen.DocService/GenerateDocx":     "generate:docx",
+				"/docgen.DocService/Generate":         "generate:any",
+				"/docgen.DocService/MergeDocuments":   "generate:merge",
+				"/docgen.DocService/VerifyPDF":        "documents:verify",
+				"/docgen.DocService/LookupDocument":   "documents:read",
+				"/docgen.DocService/QueryAudit":       "admin",
+				"/docgen.KeyAdmin/*":                  "admin",
+			},
+			JWT: JWT{
+				Leeway:      time.Minute,
+				JWKSRefresh: time.Hour,
+				Claims:      JWTClaims{Subject: "sub", Scopes: "scope", Tenant: "tenant", Name: "name"},
+			},
+		},
+		Logging: Logging{Level: "info", Format: "json"},
+		Storage: Storage{
+			AuditFile:       "audit.jsonl",
+			CacheSize:       256 << 20,
+			CacheTTL:        time.Hour,
+			IdempotencyTTL:  24 * time.Hour,
+			IdempotencySize: 128 << 20,
+		},
+		Documents: Documents{SanitizePolicy: "strip"},
+	}
+}
+
+// Validate checks the whole configuration and reports every problem at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, a ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, a...))
+		}
+	}
+	addrs := map[string]string{}
+	for _, l := range []struct{ key, addr string }{
+		{"listen.grpc", c.Listen.GRPC}, {"listen.http", c.Listen.HTTP}, {"listen.metrics", c.Listen.Metrics},
+	} {
+		if l.addr == "" {
+			check(l.key != "listen.grpc", "%s: required", l.key)
+			continue
+		}
+		if _, port, err := net.SplitHostPort(l.addr); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", l.key, err))
+		} else if other, dup := addrs[port]; dup && port != "0" {
+			errs = append(errs, fmt.Errorf("%s: port %s already used by %s", l.key, port, other))
+		} else {
+			addrs[port] = l.key
+		}
+	}
+
+	if c.TLS.Enabled() {
+		check(c.TLS.CertFile != "" && c.TLS.KeyFile != "", "tls: cert_file and key_file must be set together")
+		for _, f := range []struct{ key, path string }{{"tls.cert_file", c.TLS.CertFile}, {"tls.key_file", c.TLS.KeyFile}} {
+			if f.path != "" {
+				errs = append(errs, fileExists(f.key, f.path))
+			}
+		}
+	}
+	t := c.TLS
+	check(oneOf(t.ClientAuth, "none", "optional", "require"), "tls.client_auth: %q is not none, optional or require", t.ClientAuth)
+	if t.MutualAuth() {
+		check(t.Enabled(), "tls.client_auth: needs cert_file and key_file")
+		check(t.ClientCAFile != "", "tls.client_ca_file: required when client_auth is %s", t.ClientAuth)
+	}
+	if t.ClientCAFile != "" {
+		errs = append(errs, fileExists("tls.client_ca_file", t.ClientCAFile))
+	}
+	check(t.ReloadInterval >= 0, "tls.reload_interval: must not be negative")
+	for i, cc := range t.ClientCerts {
+		kind, name, _ := strings.Cut(cc.Match, ":")
+		check(oneOf(kind, "uri", "dns", "email", "cn") && name != "",
+			"tls.client_certs[%d].match: %q is not uri:, dns:, email: or cn: followed by a name", i, cc.Match)
+	}
+
+	check(c.Limits.RateLimit >= 0, "limits.rate_limit: must not be negative")
+	check(c.Limits.RateLimit == 0 || c.Limits.RateBurst >= 1, "limits.rate_burst: must be at least 1")
+	check(oneOf(c.Limits.RateKey, "caller", "tenant", "ip"), "limits.rate_key: %q is not caller, tenant or ip", c.Limits.RateKey)
+	check(c.Limits.DailyDocuments >= 0 && c.Limits.MonthlyDocuments >= 0, "limits: document quotas must not be negative")
+	for key, cl := range c.Limits.Clients {
+		kind, name, _ := strings.Cut(key, ":")
+		check(oneOf(kind, "key", "jwt", "cert", "tenant", "ip") && name != "",
+			"limits.clients: %q is not key:, jwt:, cert:, tenant: or ip: followed by a name", key)
+		check((cl.RateLimit == nil || *cl.RateLimit >= 0) && (cl.RateBurst == nil || *cl.RateBurst >= 1) &&
+			(cl.DailyDocuments == nil || *cl.DailyDocuments >= 0) && (cl.MonthlyDocuments == nil || *cl.MonthlyDocuments >= 0),
+			"limits.clients[%s]: limits must not be negative and rate_burst at least 1", key)
+	}
+	check(c.Limits.MaxMessageSize > 0, "limits.max_message_size: must be positive")
+	d := c.Limits.Docx
+	check(d.MaxCompressedSize >= 0 && d.MaxUncompressedSize >= 0 && d.MaxEntries >= 0 &&
+		d.MaxCompressionRatio >= 0 && d.MaxXMLDepth >= 0, "limits.docx: limits must not be negative")
+	check(d.MaxCompressedSize == 0 || d.MaxCompressedSize <= c.Limits.MaxMessageSize,
+		"limits.docx.max_compressed_size: %v is larger than limits.max_message_size %v", d.MaxCompressedSize, c.Limits.MaxMessageSize)
+
+	check(c.Converter.Workers >= 1, "converter.workers: must be at least 1")
+	if c.Converter.Soffice != "" {
+		errs = append(errs, fileExists("converter.soffice", c.Converter.Soffice))
+	}
+	sb := c.Converter.Sandbox
+	check(sb.CPUTime >= 0 && sb.WallTime >= 0 && sb.AddressSpace >= 0 && sb.FileSize >= 0,
+		"converter.sandbox: limits must not be negative")
+
+	check(len(c.Auth.APIKeys) > 0 || c.Auth.KeysFile != "" || c.Auth.JWT.Enabled() || t.MutualAuth(),
+		"auth: api_keys (DOCGEN_API_KEYS), keys_file (DOCGEN_KEYS_FILE), jwt or tls.client_auth is required")
+	if j := c.Auth.JWT; j.Enabled() {
+		check(j.Issuer != "", "auth.jwt.issuer: required when JWTs are enabled")
+		check(len(j.Audience) > 0, "auth.jwt.audience: required when JWTs are enabled")
+		check(j.HS256Secret == "" || len(j.HS256Secret) >= 32, "auth.jwt.hs256_secret: must be at least 32 bytes")
+		check(j.JWKSFile == "" || j.JWKSURL == "", "auth.jwt: jwks_file and jwks_url are exclusive")
+		if j.JWKSFile != "" {
+			errs = append(errs, fileExists("auth.jwt.jwks_file", j.JWKSFile))
+		}
+		if j.JWKSURL != "" {
+			u, err := url.Parse(j.JWKSURL)
+			ok := err == nil && (u.Scheme == "https" || u.Scheme == "http" && isLoopback(u.Hostname()))
+			check(ok, "auth.jwt.jwks_url: %q must be an https URL", j.JWKSURL)
+		}
+		check(j.Leeway >= 0 && j.JWKSRefresh >= 0, "auth.jwt: durations must not be negative")
+		check(j.Claims.Subject != "", "auth.jwt.claims.subject: required")
+	}
+	check(c.Auth.WatchInterval >= 0, "auth.watch_interval: must not be negative")
+	for m := range c.Auth.MethodScopes {
+		svc, name, ok := strings.Cut(strings.TrimPrefix(m, "/"), "/")
+		check(strings.HasPrefix(m, "/") && ok && svc != "" && name != "",
+			"auth.method_scopes: %q is not a full method name like /docgen.DocService/GeneratePDF", m)
+	}
+	for i, k := range c.Auth.APIKeys {
+		check(strings.TrimSpace(k) != "", "auth.api_keys[%d]: empty key", i)
+	}
+
+	check(oneOf(c.Logging.Level, "debug", "info", "warn", "error"), "logging.level: %q is not debug, info, warn or error", c.Logging.Level)
+	check(oneOf(c.Logging.Format, "json", "console"), "logging.format: %q is not json or console", c.Logging.Format)
+
+	check(c.Storage.AuditFile != "", "storage.audit_file: required")
+	check(c.Storage.CacheSize >= 0, "storage.cache_size: must not be negative")
+	check(c.Storage.CacheTTL > 0 || c.Storage.CacheSize == 0, "storage.cache_ttl: must be positive")
+	check(c.Storage.IdempotencyTTL > 0, "storage.idempotency_ttl: must be positive")
+	check(c.Storage.IdempotencySize > 0, "storage.idempotency_size: must be positive")
+
+	for _, f := range []struct{ key, path string }{
+		{"documents.signers_file", c.Documents.SignersFile}, {"documents.trust_roots", c.Documents.TrustRoots},
+	} {
+		if f.path != "" {
+			errs = append(errs, fileExists(f.key, f.path))
+		}
+	}
+	if c.Documents.VerifyURL != "" {
+		u, err := url.Parse(strings.ReplaceAll(c.Documents.VerifyURL, "{code}", "x"))
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"documents.verify_url: %q is not an http(s) URL", c.Documents.VerifyURL)
+	}
+	check(oneOf(strings.ToLower(c.Documents.SanitizePolicy), "strip", "reject"),
+		"documents.sanitize_policy: %q is not strip or reject", c.Documents.SanitizePolicy)
+
+	for name, tn := range c.Tenants {
+		check(strings.TrimSpace(name) != "", "tenants: empty tenant name")
+		check(tn.Signer == "" || c.Documents.SignersFile != "", "tenants.%s.signer: needs documents.signers_file", name)
+		for i, dir := range tn.FontDirs {
+			key := fmt.Sprintf("tenants.%s.font_dirs[%d]", name, i)
+			// /tmp tidak terlihat dari sandbox LibreOffice
+			check(filepath.IsAbs(dir) && dir != "/tmp" && !strings.HasPrefix(dir, "/tmp/"),
+				"%s: %q must be an absolute path outside /tmp", key, dir)
+			if fi, err := os.Stat(dir); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", key, err))
+			} else {
+				check(fi.IsDir(), "%s: %s is not a directory", key, dir)
+			}
+		}
+		if wm := tn.Watermark; wm != nil {
+			check(wm.Text != "" || wm.ImageFile != "", "tenants.%s.watermark: text or image_file is required", name)
+			if wm.ImageFile != "" {
+				errs = append(errs, fileExists(fmt.Sprintf("tenants.%s.watermark.image_file", name), wm.ImageFile))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func fileExists(key, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func oneOf(s string, values ...string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteYAML writes c as YAML, with API keys and secrets masked.
+func (c *Config) WriteYAML(w io.Writer) error {
+	out := *c
+	out.Auth.APIKeys = make([]string, len(c.Auth.APIKeys))
+	for i := range out.Auth.APIKeys {
+		out.Auth.APIKeys[i] = "<redacted>"
+	}
+	if out.Auth.JWT.HS256Secret != "" {
+		out.Auth.JWT.HS256Secret = "<redacted>"
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&out); err != nil {
+		return err
+	}
+	return enc.Close()
+}
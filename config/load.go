@@ -0,0 +1,246 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigFile names the YAML file when --config is not given.
+const EnvConfigFile = "DOCGEN_CONFIG"
+
+// Load builds the configuration from defaults, the config file, the
+// environment and args (without the program name); it does not validate the
+// result, see Validate. printConfig reports --print-config. With -h, Load
+// prints the usage to stderr and returns flag.ErrHelp.
+func Load(args []string) (cfg *Config, printConfig bool, err error) {
+	cfg = Default()
+	fields := fieldsOf(cfg)
+
+	fs := flag.NewFlagSet("docsvc", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(EnvConfigFile), "YAML config file (env "+EnvConfigFile+")")
+	fs.BoolVar(&printConfig, "print-config", false, "print the effective configuration (API keys masked) and exit")
+	// flag disimpan dulu, baru diterapkan setelah file dan env
+	type setting struct {
+		f     field
+		value string
+	}
+	var fromFlags []setting
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+		f := f
+		help := f.usage + " (env " + f.env
+		if def := f.String(); def != "" {
+			help += ", default " + def
+		}
+		fs.Func(f.flag, help+")", func(s string) error {
+			fromFlags = append(fromFlags, setting{f, s})
+			return nil
+		})
+	}
+	fs.Usage = func() { usage(fs, fields) }
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+	if fs.NArg() > 0 {
+		return nil, false, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, false, err
+		}
+	}
+	for _, f := range fields {
+		if v, ok := os.LookupEnv(f.env); ok && f.env != "" {
+			if err := f.set(v); err != nil {
+				return nil, false, fmt.Errorf("%s: %v", f.env, err)
+			}
+		}
+	}
+	for _, s := range fromFlags {
+		if err := s.f.set(s.value); err != nil {
+			return nil, false, fmt.Errorf("-%s: %v", s.f.flag, err)
+		}
+	}
+	return cfg, printConfig, nil
+}
+
+// loadFile overlays the YAML file on c; unknown keys are errors so that typos
+// do not silently fall back to defaults.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %v", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %v", path, err)
+	}
+	return nil
+}
+
+func usage(fs *flag.FlagSet, fields []field) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: docsvc [flags]\n\nPrecedence: flags > environment > config file > defaults.\n\nFlags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nEnvironment (config file key in brackets):\n")
+	for _, f := range fields {
+		if f.env != "" {
+			fmt.Fprintf(w, "  %-36s [%s] %s\n", f.env, f.key, f.usage)
+		}
+	}
+}
+
+// field is one settable leaf of Config.
+type field struct {
+	key, env, flag, usage string
+	v                     reflect.Value
+}
+
+func fieldsOf(c *Config) []field {
+	var out []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := prefix + strings.Split(sf.Tag.Get("yaml"), ",")[0]
+			if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+				walk(v.Field(i), key+".")
+				continue
+			}
+			out = append(out, field{
+				key:   key,
+				env:   sf.Tag.Get("env"),
+				flag:  sf.Tag.Get("flag"),
+				usage: sf.Tag.Get("usage"),
+				v:     v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+	return out
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses s the way it is written in the environment: durations as
+// "90s", sizes as "256MiB", lists comma-separated.
+func (f field) set(s string) error {
+	v := f.v
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	s = strings.TrimSpace(s)
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case v.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		var list []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	switch x := f.v.Interface().(type) {
+	case time.Duration:
+		return x.String()
+	case ByteSize:
+		return x.String()
+	case []string:
+		return strings.Join(x, ",")
+	}
+	return fmt.Sprint(f.v.Interface())
+}
+
+// ByteSize is a size in bytes, written as a plain number or with a unit:
+// "512KiB", "256MiB", "4GiB" (KB/MB/GB are decimal).
+type ByteSize int64
+
+var byteUnits = []struct {
+	suffix string
+	n      int64
+}{
+	{"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3}, {"B", 1},
+}
+
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	mult := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.n
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid size %q", text)
+	}
+	*b = ByteSize(n * mult)
+	return nil
+}
+
+func (b ByteSize) MarshalText() ([]byte, error) { return []byte(b.String()), nil }
+
+func (b ByteSize) String() string {
+	for _, u := range byteUnits[:3] {
+		if b != 0 && int64(b)%u.n == 0 {
+			return strconv.FormatInt(int64(b)/u.n, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(b), 10)
+}
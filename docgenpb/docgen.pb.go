@@ -21,6 +21,177 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OutputFormat int32
+
+const (
+	OutputFormat_OUTPUT_FORMAT_UNSPECIFIED OutputFormat = 0
+	OutputFormat_OUTPUT_FORMAT_PDF         OutputFormat = 1
+	OutputFormat_OUTPUT_FORMAT_DOCX        OutputFormat = 2
+	OutputFormat_OUTPUT_FORMAT_ODT         OutputFormat = 3
+	OutputFormat_OUTPUT_FORMAT_RTF         OutputFormat = 4
+	OutputFormat_OUTPUT_FORMAT_HTML        OutputFormat = 5 // gambar di-embed sebagai data URI
+	OutputFormat_OUTPUT_FORMAT_TXT         OutputFormat = 6 // plain text UTF-8, tanpa LibreOffice
+	OutputFormat_OUTPUT_FORMAT_PNG         OutputFormat = 7 // dokumen satu halaman saja (96 dpi); lebih = InvalidArgument
+	OutputFormat_OUTPUT_FORMAT_JPEG        OutputFormat = 8 // idem, butuh pdftoppm seperti RenderPreview
+)
+
+// Enum value maps for OutputFormat.
+var (
+	OutputFormat_name = map[int32]string{
+		0: "OUTPUT_FORMAT_UNSPECIFIED",
+		1: "OUTPUT_FORMAT_PDF",
+		2: "OUTPUT_FORMAT_DOCX",
+		3: "OUTPUT_FORMAT_ODT",
+		4: "OUTPUT_FORMAT_RTF",
+		5: "OUTPUT_FORMAT_HTML",
+		6: "OUTPUT_FORMAT_TXT",
+		7: "OUTPUT_FORMAT_PNG",
+		8: "OUTPUT_FORMAT_JPEG",
+	}
+	OutputFormat_value = map[string]int32{
+		"OUTPUT_FORMAT_UNSPECIFIED": 0,
+		"OUTPUT_FORMAT_PDF":         1,
+		"OUTPUT_FORMAT_DOCX":        2,
+		"OUTPUT_FORMAT_ODT":         3,
+		"OUTPUT_FORMAT_RTF":         4,
+		"OUTPUT_FORMAT_HTML":        5,
+		"OUTPUT_FORMAT_TXT":         6,
+		"OUTPUT_FORMAT_PNG":         7,
+		"OUTPUT_FORMAT_JPEG":        8,
+	}
+)
+
+func (x OutputFormat) Enum() *OutputFormat {
+	p := new(OutputFormat)
+	*p = x
+	return p
+}
+
+func (x OutputFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_docgen_proto_enumTypes[0].Descriptor()
+}
+
+func (OutputFormat) Type() protoreflect.EnumType {
+	return &file_docgen_proto_enumTypes[0]
+}
+
+func (x OutputFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputFormat.Descriptor instead.
+func (OutputFormat) EnumDescriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{0}
+}
+
+type PdfALevel int32
+
+const (
+	PdfALevel_PDFA_NONE PdfALevel = 0
+	PdfALevel_PDFA_1B   PdfALevel = 1
+	PdfALevel_PDFA_2B   PdfALevel = 2
+	PdfALevel_PDFA_3B   PdfALevel = 3
+)
+
+// Enum value maps for PdfALevel.
+var (
+	PdfALevel_name = map[int32]string{
+		0: "PDFA_NONE",
+		1: "PDFA_1B",
+		2: "PDFA_2B",
+		3: "PDFA_3B",
+	}
+	PdfALevel_value = map[string]int32{
+		"PDFA_NONE": 0,
+		"PDFA_1B":   1,
+		"PDFA_2B":   2,
+		"PDFA_3B":   3,
+	}
+)
+
+func (x PdfALevel) Enum() *PdfALevel {
+	p := new(PdfALevel)
+	*p = x
+	return p
+}
+
+func (x PdfALevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PdfALevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_docgen_proto_enumTypes[1].Descriptor()
+}
+
+func (PdfALevel) Type() protoreflect.EnumType {
+	return &file_docgen_proto_enumTypes[1]
+}
+
+func (x PdfALevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PdfALevel.Descriptor instead.
+func (PdfALevel) EnumDescriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{1}
+}
+
+type DiagnosticSeverity int32
+
+const (
+	DiagnosticSeverity_SEVERITY_UNSPECIFIED DiagnosticSeverity = 0
+	DiagnosticSeverity_SEVERITY_ERROR       DiagnosticSeverity = 1 // template akan gagal atau hasilnya salah
+	DiagnosticSeverity_SEVERITY_WARNING     DiagnosticSeverity = 2 // kemungkinan hasil berbeda dari yang diharapkan
+	DiagnosticSeverity_SEVERITY_INFO        DiagnosticSeverity = 3
+)
+
+// Enum value maps for DiagnosticSeverity.
+var (
+	DiagnosticSeverity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_ERROR",
+		2: "SEVERITY_WARNING",
+		3: "SEVERITY_INFO",
+	}
+	DiagnosticSeverity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_ERROR":       1,
+		"SEVERITY_WARNING":     2,
+		"SEVERITY_INFO":        3,
+	}
+)
+
+func (x DiagnosticSeverity) Enum() *DiagnosticSeverity {
+	p := new(DiagnosticSeverity)
+	*p = x
+	return p
+}
+
+func (x DiagnosticSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiagnosticSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_docgen_proto_enumTypes[2].Descriptor()
+}
+
+func (DiagnosticSeverity) Type() protoreflect.EnumType {
+	return &file_docgen_proto_enumTypes[2]
+}
+
+func (x DiagnosticSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiagnosticSeverity.Descriptor instead.
+func (DiagnosticSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{2}
+}
+
 type TemplateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Template      []byte                 `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"` // raw file .docx
@@ -110,12 +281,20 @@ func (x *PlaceholderResponse) GetPlaceholders() []string {
 }
 
 type GenerateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Template      []byte                 `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`                                                                   // raw file .docx
-	Data          map[string]string      `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // k/v untuk {{key}}
-	FilenameHint  string                 `protobuf:"bytes,3,opt,name=filename_hint,json=filenameHint,proto3" json:"filename_hint,omitempty"`                                       // opsional: nama file dasar (tanpa ekstensi)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Template       []byte                 `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`                                                                   // raw file .docx
+	Data           map[string]string      `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // k/v untuk {{key}}
+	FilenameHint   string                 `protobuf:"bytes,3,opt,name=filename_hint,json=filenameHint,proto3" json:"filename_hint,omitempty"`                                       // opsional: nama file dasar (tanpa ekstensi)
+	BypassCache    bool                   `protobuf:"varint,4,opt,name=bypass_cache,json=bypassCache,proto3" json:"bypass_cache,omitempty"`                                         // opsional: paksa render ulang, abaikan result cache
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                 // opsional: sama dengan metadata "idempotency-key"
+	OutputFormat   OutputFormat           `protobuf:"varint,6,opt,name=output_format,json=outputFormat,proto3,enum=docgen.OutputFormat" json:"output_format,omitempty"`             // wajib untuk Generate; diabaikan GeneratePDF/GenerateDocx
+	Pdf            *PdfOptions            `protobuf:"bytes,7,opt,name=pdf,proto3" json:"pdf,omitempty"`                                                                             // opsional: hanya untuk output PDF
+	Security       *PdfSecurity           `protobuf:"bytes,8,opt,name=security,proto3" json:"security,omitempty"`                                                                   // opsional: enkripsi AES-256 hasil PDF
+	Watermark      *Watermark             `protobuf:"bytes,9,opt,name=watermark,proto3" json:"watermark,omitempty"`                                                                 // opsional: PDF di-stamp, format lain lewat header DOCX; tidak untuk PDF/A
+	Signature      *PdfSignature          `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`                                                                // opsional: tanda tangan digital PAdES, hanya output PDF
+	Verification   *VerificationStamp     `protobuf:"bytes,11,opt,name=verification,proto3" json:"verification,omitempty"`                                                          // opsional: kode unik + QR ke halaman verifikasi, hanya output PDF
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GenerateRequest) Reset() {
@@ -169,29 +348,89 @@ func (x *GenerateRequest) GetFilenameHint() string {
 	return ""
 }
 
-type GenerateResponse struct {
+func (x *GenerateRequest) GetBypassCache() bool {
+	if x != nil {
+		return x.BypassCache
+	}
+	return false
+}
+
+func (x *GenerateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetOutputFormat() OutputFormat {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+}
+
+func (x *GenerateRequest) GetPdf() *PdfOptions {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *GenerateRequest) GetSecurity() *PdfSecurity {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+func (x *GenerateRequest) GetWatermark() *Watermark {
+	if x != nil {
+		return x.Watermark
+	}
+	return nil
+}
+
+func (x *GenerateRequest) GetSignature() *PdfSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *GenerateRequest) GetVerification() *VerificationStamp {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+// Kode verifikasi unik per dokumen, di-stamp sebagai QR (URL halaman verifikasi
+// server) + teks. Stamp dipasang sebelum enkripsi dan tanda tangan.
+type VerificationStamp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                            // PDF atau DOCX (sesuai RPC)
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // application/pdf atau application/vnd.openxmlformats-officedocument.wordprocessingml.document
-	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`                          // nama file saran (mis. result.pdf)
+	Position      string                 `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`                  // "br" (default), "bl", "tr", "tl"
+	AllPages      bool                   `protobuf:"varint,2,opt,name=all_pages,json=allPages,proto3" json:"all_pages,omitempty"` // default hanya halaman terakhir
+	Size          float64                `protobuf:"fixed64,3,opt,name=size,proto3" json:"size,omitempty"`                        // sisi QR dalam pt, default 56
+	Margin        float64                `protobuf:"fixed64,4,opt,name=margin,proto3" json:"margin,omitempty"`                    // jarak dari tepi halaman dalam pt, default 24
+	Label         string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`                        // teks di samping QR, "{code}" diganti kode; default "Kode verifikasi: {code}"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateResponse) Reset() {
-	*x = GenerateResponse{}
+func (x *VerificationStamp) Reset() {
+	*x = VerificationStamp{}
 	mi := &file_docgen_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateResponse) String() string {
+func (x *VerificationStamp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateResponse) ProtoMessage() {}
+func (*VerificationStamp) ProtoMessage() {}
 
-func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+func (x *VerificationStamp) ProtoReflect() protoreflect.Message {
 	mi := &file_docgen_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -203,134 +442,2963 @@ func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
-func (*GenerateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerificationStamp.ProtoReflect.Descriptor instead.
+func (*VerificationStamp) Descriptor() ([]byte, []int) {
 	return file_docgen_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GenerateResponse) GetContent() []byte {
+func (x *VerificationStamp) GetPosition() string {
 	if x != nil {
-		return x.Content
+		return x.Position
 	}
-	return nil
+	return ""
 }
 
-func (x *GenerateResponse) GetContentType() string {
+func (x *VerificationStamp) GetAllPages() bool {
 	if x != nil {
-		return x.ContentType
+		return x.AllPages
+	}
+	return false
+}
+
+func (x *VerificationStamp) GetSize() float64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *VerificationStamp) GetMargin() float64 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *VerificationStamp) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
 	return ""
 }
 
-func (x *GenerateResponse) GetFilename() string {
+// Tanda tangan digital (PAdES, CMS detached) dengan sertifikat yang dikonfigurasi di server.
+type PdfSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       string                 `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`   // nama profil signer di server; kosong = "default"
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`     // kosong = default profil
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"` // kosong = default profil
+	ContactInfo   string                 `protobuf:"bytes,4,opt,name=contact_info,json=contactInfo,proto3" json:"contact_info,omitempty"`
+	Timestamp     bool                   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`  // tambahkan timestamp RFC 3161 dari TSA profil
+	Appearance    *SignatureAppearance   `protobuf:"bytes,6,opt,name=appearance,proto3" json:"appearance,omitempty"` // kosong = tanda tangan tidak terlihat
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PdfSignature) Reset() {
+	*x = PdfSignature{}
+	mi := &file_docgen_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PdfSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PdfSignature) ProtoMessage() {}
+
+func (x *PdfSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[4]
 	if x != nil {
-		return x.Filename
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PdfSignature.ProtoReflect.Descriptor instead.
+func (*PdfSignature) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PdfSignature) GetProfile() string {
+	if x != nil {
+		return x.Profile
 	}
 	return ""
 }
 
-var File_docgen_proto protoreflect.FileDescriptor
+func (x *PdfSignature) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
 
-var file_docgen_proto_rawDesc = string([]byte{
-	0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x22, 0x2d, 0x0a, 0x0f, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x39, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f,
-	0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c,
-	0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73,
-	0x22, 0xc2, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x12, 0x35, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
-	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x1a, 0x37, 0x0a, 0x09,
-	0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6b, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x32, 0xda, 0x01, 0x0a, 0x0a, 0x44, 0x6f, 0x63, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x47, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c,
-	0x64, 0x65, 0x72, 0x73, 0x12, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x54, 0x65,
-	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0b, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x44, 0x46, 0x12, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67,
-	0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0c,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x63, 0x78, 0x12, 0x17, 0x2e, 0x64,
-	0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
-	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x65,
-	0x64, 0x69, 0x6e, 0x69, 0x72, 0x74, 0x61, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x61, 0x2f, 0x64, 0x6f,
-	0x63, 0x78, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x70, 0x62, 0x3b,
-	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+func (x *PdfSignature) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
 
-var (
-	file_docgen_proto_rawDescOnce sync.Once
-	file_docgen_proto_rawDescData []byte
-)
+func (x *PdfSignature) GetContactInfo() string {
+	if x != nil {
+		return x.ContactInfo
+	}
+	return ""
+}
 
-func file_docgen_proto_rawDescGZIP() []byte {
-	file_docgen_proto_rawDescOnce.Do(func() {
-		file_docgen_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_docgen_proto_rawDesc), len(file_docgen_proto_rawDesc)))
-	})
-	return file_docgen_proto_rawDescData
+func (x *PdfSignature) GetTimestamp() bool {
+	if x != nil {
+		return x.Timestamp
+	}
+	return false
 }
 
-var file_docgen_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_docgen_proto_goTypes = []any{
-	(*TemplateRequest)(nil),     // 0: docgen.TemplateRequest
-	(*PlaceholderResponse)(nil), // 1: docgen.PlaceholderResponse
-	(*GenerateRequest)(nil),     // 2: docgen.GenerateRequest
-	(*GenerateResponse)(nil),    // 3: docgen.GenerateResponse
-	nil,                         // 4: docgen.GenerateRequest.DataEntry
+func (x *PdfSignature) GetAppearance() *SignatureAppearance {
+	if x != nil {
+		return x.Appearance
+	}
+	return nil
 }
-var file_docgen_proto_depIdxs = []int32{
-	4, // 0: docgen.GenerateRequest.data:type_name -> docgen.GenerateRequest.DataEntry
-	0, // 1: docgen.DocService.GetPlaceholders:input_type -> docgen.TemplateRequest
-	2, // 2: docgen.DocService.GeneratePDF:input_type -> docgen.GenerateRequest
-	2, // 3: docgen.DocService.GenerateDocx:input_type -> docgen.GenerateRequest
-	1, // 4: docgen.DocService.GetPlaceholders:output_type -> docgen.PlaceholderResponse
-	3, // 5: docgen.DocService.GeneratePDF:output_type -> docgen.GenerateResponse
-	3, // 6: docgen.DocService.GenerateDocx:output_type -> docgen.GenerateResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Kotak tanda tangan yang terlihat di halaman. Koordinat dalam point dari kiri bawah.
+type SignatureAppearance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"` // 1-based; 0 = halaman terakhir
+	X             float64                `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	Width         float64                `protobuf:"fixed64,4,opt,name=width,proto3" json:"width,omitempty"`   // default 200
+	Height        float64                `protobuf:"fixed64,5,opt,name=height,proto3" json:"height,omitempty"` // default 60
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`       // kosong = nama penanda tangan, waktu, alasan, lokasi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func init() { file_docgen_proto_init() }
-func file_docgen_proto_init() {
-	if File_docgen_proto != nil {
-		return
+func (x *SignatureAppearance) Reset() {
+	*x = SignatureAppearance{}
+	mi := &file_docgen_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignatureAppearance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureAppearance) ProtoMessage() {}
+
+func (x *SignatureAppearance) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureAppearance.ProtoReflect.Descriptor instead.
+func (*SignatureAppearance) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SignatureAppearance) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SignatureAppearance) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *SignatureAppearance) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *SignatureAppearance) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *SignatureAppearance) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SignatureAppearance) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Watermark teks (mis. "DRAFT", "SALINAN") atau gambar di setiap halaman.
+type Watermark struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Image         []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`                         // PNG/JPEG, dipakai jika text kosong
+	FontSize      float64                `protobuf:"fixed64,3,opt,name=font_size,json=fontSize,proto3" json:"font_size,omitempty"` // pt; 0 = diskalakan selebar ~1/2 halaman
+	Color         string                 `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`                         // "#RRGGBB", default "#808080"
+	Opacity       float64                `protobuf:"fixed64,5,opt,name=opacity,proto3" json:"opacity,omitempty"`                   // 0-1, default 0.3
+	Rotation      *float64               `protobuf:"fixed64,6,opt,name=rotation,proto3,oneof" json:"rotation,omitempty"`           // derajat berlawanan jarum jam, default 45 (diagonal)
+	Pages         string                 `protobuf:"bytes,7,opt,name=pages,proto3" json:"pages,omitempty"`                         // mis. "1-3,5"; kosong = semua. Hanya untuk output PDF
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Watermark) Reset() {
+	*x = Watermark{}
+	mi := &file_docgen_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Watermark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Watermark) ProtoMessage() {}
+
+func (x *Watermark) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Watermark.ProtoReflect.Descriptor instead.
+func (*Watermark) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Watermark) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Watermark) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *Watermark) GetFontSize() float64 {
+	if x != nil {
+		return x.FontSize
+	}
+	return 0
+}
+
+func (x *Watermark) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Watermark) GetOpacity() float64 {
+	if x != nil {
+		return x.Opacity
+	}
+	return 0
+}
+
+func (x *Watermark) GetRotation() float64 {
+	if x != nil && x.Rotation != nil {
+		return *x.Rotation
+	}
+	return 0
+}
+
+func (x *Watermark) GetPages() string {
+	if x != nil {
+		return x.Pages
+	}
+	return ""
+}
+
+// Enkripsi & pembatasan hak akses PDF (AES-256).
+type PdfSecurity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserPassword  string                 `protobuf:"bytes,1,opt,name=user_password,json=userPassword,proto3" json:"user_password,omitempty"`    // password untuk membuka dokumen (mis. NIK / tanggal lahir)
+	OwnerPassword string                 `protobuf:"bytes,2,opt,name=owner_password,json=ownerPassword,proto3" json:"owner_password,omitempty"` // password untuk ubah hak akses; kosong = acak
+	NoPrint       bool                   `protobuf:"varint,3,opt,name=no_print,json=noPrint,proto3" json:"no_print,omitempty"`
+	NoCopy        bool                   `protobuf:"varint,4,opt,name=no_copy,json=noCopy,proto3" json:"no_copy,omitempty"`       // larang copy/extract teks & gambar
+	NoModify      bool                   `protobuf:"varint,5,opt,name=no_modify,json=noModify,proto3" json:"no_modify,omitempty"` // larang ubah isi, anotasi, form & susunan halaman
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PdfSecurity) Reset() {
+	*x = PdfSecurity{}
+	mi := &file_docgen_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PdfSecurity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PdfSecurity) ProtoMessage() {}
+
+func (x *PdfSecurity) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PdfSecurity.ProtoReflect.Descriptor instead.
+func (*PdfSecurity) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PdfSecurity) GetUserPassword() string {
+	if x != nil {
+		return x.UserPassword
+	}
+	return ""
+}
+
+func (x *PdfSecurity) GetOwnerPassword() string {
+	if x != nil {
+		return x.OwnerPassword
+	}
+	return ""
+}
+
+func (x *PdfSecurity) GetNoPrint() bool {
+	if x != nil {
+		return x.NoPrint
+	}
+	return false
+}
+
+func (x *PdfSecurity) GetNoCopy() bool {
+	if x != nil {
+		return x.NoCopy
+	}
+	return false
+}
+
+func (x *PdfSecurity) GetNoModify() bool {
+	if x != nil {
+		return x.NoModify
+	}
+	return false
+}
+
+// Opsi LibreOffice writer_pdf_Export. Field yang tidak di-set memakai default LibreOffice.
+type PdfOptions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Pdfa               PdfALevel              `protobuf:"varint,1,opt,name=pdfa,proto3,enum=docgen.PdfALevel" json:"pdfa,omitempty"`                                         // hasil diverifikasi punya identifikasi PDF/A
+	Tagged             *bool                  `protobuf:"varint,2,opt,name=tagged,proto3,oneof" json:"tagged,omitempty"`                                                     // tagged PDF (aksesibilitas)
+	EmbedStandardFonts *bool                  `protobuf:"varint,3,opt,name=embed_standard_fonts,json=embedStandardFonts,proto3,oneof" json:"embed_standard_fonts,omitempty"` // embed juga 14 font standar PDF
+	JpegQuality        int32                  `protobuf:"varint,4,opt,name=jpeg_quality,json=jpegQuality,proto3" json:"jpeg_quality,omitempty"`                              // 1-100, 0 = default (90)
+	LosslessImages     *bool                  `protobuf:"varint,5,opt,name=lossless_images,json=losslessImages,proto3,oneof" json:"lossless_images,omitempty"`               // kompresi gambar lossless, bukan JPEG
+	MaxImageDpi        int32                  `protobuf:"varint,6,opt,name=max_image_dpi,json=maxImageDpi,proto3" json:"max_image_dpi,omitempty"`                            // >0: turunkan resolusi gambar ke DPI ini
+	ExportBookmarks    *bool                  `protobuf:"varint,7,opt,name=export_bookmarks,json=exportBookmarks,proto3,oneof" json:"export_bookmarks,omitempty"`
+	ExportFormFields   *bool                  `protobuf:"varint,8,opt,name=export_form_fields,json=exportFormFields,proto3,oneof" json:"export_form_fields,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PdfOptions) Reset() {
+	*x = PdfOptions{}
+	mi := &file_docgen_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PdfOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PdfOptions) ProtoMessage() {}
+
+func (x *PdfOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PdfOptions.ProtoReflect.Descriptor instead.
+func (*PdfOptions) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PdfOptions) GetPdfa() PdfALevel {
+	if x != nil {
+		return x.Pdfa
+	}
+	return PdfALevel_PDFA_NONE
+}
+
+func (x *PdfOptions) GetTagged() bool {
+	if x != nil && x.Tagged != nil {
+		return *x.Tagged
+	}
+	return false
+}
+
+func (x *PdfOptions) GetEmbedStandardFonts() bool {
+	if x != nil && x.EmbedStandardFonts != nil {
+		return *x.EmbedStandardFonts
+	}
+	return false
+}
+
+func (x *PdfOptions) GetJpegQuality() int32 {
+	if x != nil {
+		return x.JpegQuality
+	}
+	return 0
+}
+
+func (x *PdfOptions) GetLosslessImages() bool {
+	if x != nil && x.LosslessImages != nil {
+		return *x.LosslessImages
+	}
+	return false
+}
+
+func (x *PdfOptions) GetMaxImageDpi() int32 {
+	if x != nil {
+		return x.MaxImageDpi
+	}
+	return 0
+}
+
+func (x *PdfOptions) GetExportBookmarks() bool {
+	if x != nil && x.ExportBookmarks != nil {
+		return *x.ExportBookmarks
+	}
+	return false
+}
+
+func (x *PdfOptions) GetExportFormFields() bool {
+	if x != nil && x.ExportFormFields != nil {
+		return *x.ExportFormFields
+	}
+	return false
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                               // hasil sesuai RPC / output_format
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`    // mis. application/pdf, application/vnd.oasis.opendocument.text
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`                             // nama file saran (mis. result.pdf)
+	DocumentCode  string                 `protobuf:"bytes,4,opt,name=document_code,json=documentCode,proto3" json:"document_code,omitempty"` // kode verifikasi, jika diminta
+	Sanitized     []*SanitizedItem       `protobuf:"bytes,5,rep,name=sanitized,proto3" json:"sanitized,omitempty"`                           // konten aktif yang dibuang dari template sebelum render
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_docgen_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *GenerateResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetDocumentCode() string {
+	if x != nil {
+		return x.DocumentCode
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetSanitized() []*SanitizedItem {
+	if x != nil {
+		return x.Sanitized
+	}
+	return nil
+}
+
+// Satu hal yang dibuang sanitizer dari DOCX masukan.
+type SanitizedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`     // "vba_project", "activex", "ole_object", "external_relationship"
+	Part          string                 `protobuf:"bytes,2,opt,name=part,proto3" json:"part,omitempty"`     // part yang dihapus, atau part pemilik relasi
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"` // target relasi eksternal
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"` // "template", atau "source N" untuk merge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SanitizedItem) Reset() {
+	*x = SanitizedItem{}
+	mi := &file_docgen_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SanitizedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizedItem) ProtoMessage() {}
+
+func (x *SanitizedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizedItem.ProtoReflect.Descriptor instead.
+func (*SanitizedItem) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SanitizedItem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SanitizedItem) GetPart() string {
+	if x != nil {
+		return x.Part
+	}
+	return ""
+}
+
+func (x *SanitizedItem) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SanitizedItem) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type MergeSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*MergeSource_Generate
+	//	*MergeSource_Pdf
+	//	*MergeSource_Docx
+	Source        isMergeSource_Source `protobuf_oneof:"source"`
+	Title         string               `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"` // judul bookmark (output PDF); default "Bagian N"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeSource) Reset() {
+	*x = MergeSource{}
+	mi := &file_docgen_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeSource) ProtoMessage() {}
+
+func (x *MergeSource) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeSource.ProtoReflect.Descriptor instead.
+func (*MergeSource) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MergeSource) GetSource() isMergeSource_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *MergeSource) GetGenerate() *GenerateRequest {
+	if x != nil {
+		if x, ok := x.Source.(*MergeSource_Generate); ok {
+			return x.Generate
+		}
+	}
+	return nil
+}
+
+func (x *MergeSource) GetPdf() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*MergeSource_Pdf); ok {
+			return x.Pdf
+		}
+	}
+	return nil
+}
+
+func (x *MergeSource) GetDocx() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*MergeSource_Docx); ok {
+			return x.Docx
+		}
+	}
+	return nil
+}
+
+func (x *MergeSource) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type isMergeSource_Source interface {
+	isMergeSource_Source()
+}
+
+type MergeSource_Generate struct {
+	Generate *GenerateRequest `protobuf:"bytes,1,opt,name=generate,proto3,oneof"` // template + data, dirender ke PDF dulu
+}
+
+type MergeSource_Pdf struct {
+	Pdf []byte `protobuf:"bytes,2,opt,name=pdf,proto3,oneof"` // PDF jadi (tidak boleh terenkripsi)
+}
+
+type MergeSource_Docx struct {
+	Docx []byte `protobuf:"bytes,3,opt,name=docx,proto3,oneof"` // DOCX jadi, dikonversi apa adanya
+}
+
+func (*MergeSource_Generate) isMergeSource_Source() {}
+
+func (*MergeSource_Pdf) isMergeSource_Source() {}
+
+func (*MergeSource_Docx) isMergeSource_Source() {}
+
+type MergeRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Sources      []*MergeSource         `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`                             // urutan = urutan di hasil
+	Bookmarks    bool                   `protobuf:"varint,2,opt,name=bookmarks,proto3" json:"bookmarks,omitempty"`                        // satu bookmark per bagian
+	NumberPages  bool                   `protobuf:"varint,3,opt,name=number_pages,json=numberPages,proto3" json:"number_pages,omitempty"` // nomor halaman berlanjut "n / total" di kaki halaman
+	FilenameHint string                 `protobuf:"bytes,4,opt,name=filename_hint,json=filenameHint,proto3" json:"filename_hint,omitempty"`
+	// PDF (default) atau DOCX. Untuk DOCX setiap sumber jadi section sendiri;
+	// sumber pdf, bookmarks dan number_pages tidak didukung.
+	OutputFormat  OutputFormat `protobuf:"varint,5,opt,name=output_format,json=outputFormat,proto3,enum=docgen.OutputFormat" json:"output_format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeRequest) Reset() {
+	*x = MergeRequest{}
+	mi := &file_docgen_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRequest) ProtoMessage() {}
+
+func (x *MergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRequest.ProtoReflect.Descriptor instead.
+func (*MergeRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MergeRequest) GetSources() []*MergeSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MergeRequest) GetBookmarks() bool {
+	if x != nil {
+		return x.Bookmarks
+	}
+	return false
+}
+
+func (x *MergeRequest) GetNumberPages() bool {
+	if x != nil {
+		return x.NumberPages
+	}
+	return false
+}
+
+func (x *MergeRequest) GetFilenameHint() string {
+	if x != nil {
+		return x.FilenameHint
+	}
+	return ""
+}
+
+func (x *MergeRequest) GetOutputFormat() OutputFormat {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+}
+
+type VerifyPDFRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pdf           []byte                 `protobuf:"bytes,1,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPDFRequest) Reset() {
+	*x = VerifyPDFRequest{}
+	mi := &file_docgen_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPDFRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPDFRequest) ProtoMessage() {}
+
+func (x *VerifyPDFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPDFRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPDFRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyPDFRequest) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+type VerifyPDFResponse struct {
+	state      protoimpl.MessageState   `protogen:"open.v1"`
+	Sha256     string                   `protobuf:"bytes,1,opt,name=sha256,proto3" json:"sha256,omitempty"`                           // hash (hex) dokumen yang dikirim
+	Recorded   bool                     `protobuf:"varint,2,opt,name=recorded,proto3" json:"recorded,omitempty"`                      // hash tercatat saat generate/merge di service ini
+	RecordedAt string                   `protobuf:"bytes,3,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"` // RFC 3339, jika recorded
+	Signatures []*SignatureVerification `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures,omitempty"`                   // urut sesuai revisi, yang pertama ditandatangani dulu
+	// ada tanda tangan, semuanya utuh dan terpercaya, dan tidak ada perubahan
+	// setelah tanda tangan terakhir
+	Valid         bool   `protobuf:"varint,5,opt,name=valid,proto3" json:"valid,omitempty"`
+	DocumentCode  string `protobuf:"bytes,6,opt,name=document_code,json=documentCode,proto3" json:"document_code,omitempty"` // kode verifikasi dokumen, jika recorded dan punya kode
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPDFResponse) Reset() {
+	*x = VerifyPDFResponse{}
+	mi := &file_docgen_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPDFResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPDFResponse) ProtoMessage() {}
+
+func (x *VerifyPDFResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPDFResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPDFResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VerifyPDFResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *VerifyPDFResponse) GetRecorded() bool {
+	if x != nil {
+		return x.Recorded
+	}
+	return false
+}
+
+func (x *VerifyPDFResponse) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+func (x *VerifyPDFResponse) GetSignatures() []*SignatureVerification {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *VerifyPDFResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyPDFResponse) GetDocumentCode() string {
+	if x != nil {
+		return x.DocumentCode
+	}
+	return ""
+}
+
+type SignatureVerification struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	FieldName            string                 `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
+	SubFilter            string                 `protobuf:"bytes,2,opt,name=sub_filter,json=subFilter,proto3" json:"sub_filter,omitempty"`             // mis. ETSI.CAdES.detached, adbe.pkcs7.detached
+	SignerName           string                 `protobuf:"bytes,3,opt,name=signer_name,json=signerName,proto3" json:"signer_name,omitempty"`          // CN sertifikat penanda tangan
+	SignerSubject        string                 `protobuf:"bytes,4,opt,name=signer_subject,json=signerSubject,proto3" json:"signer_subject,omitempty"` // DN lengkap
+	Issuer               string                 `protobuf:"bytes,5,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	SignerProfile        string                 `protobuf:"bytes,6,opt,name=signer_profile,json=signerProfile,proto3" json:"signer_profile,omitempty"` // nama profil signer server ini jika sertifikatnya cocok
+	SigningTime          string                 `protobuf:"bytes,7,opt,name=signing_time,json=signingTime,proto3" json:"signing_time,omitempty"`       // RFC 3339, dari /M (klaim penanda tangan, tidak terverifikasi)
+	TimestampTime        string                 `protobuf:"bytes,8,opt,name=timestamp_time,json=timestampTime,proto3" json:"timestamp_time,omitempty"` // RFC 3339, dari token RFC 3161 jika ada dan cocok
+	Reason               string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	Location             string                 `protobuf:"bytes,10,opt,name=location,proto3" json:"location,omitempty"`
+	Intact               bool                   `protobuf:"varint,11,opt,name=intact,proto3" json:"intact,omitempty"`                                                           // digest & tanda tangan CMS cocok dengan byte yang ditandatangani
+	Trusted              bool                   `protobuf:"varint,12,opt,name=trusted,proto3" json:"trusted,omitempty"`                                                         // chain sertifikat sampai ke trust root server
+	ModifiedAfterSigning bool                   `protobuf:"varint,13,opt,name=modified_after_signing,json=modifiedAfterSigning,proto3" json:"modified_after_signing,omitempty"` // ada revisi (byte) setelah bagian yang ditandatangani
+	RevisionRecorded     bool                   `protobuf:"varint,14,opt,name=revision_recorded,json=revisionRecorded,proto3" json:"revision_recorded,omitempty"`               // revisi yang ditandatangani tercatat dihasilkan service ini
+	Problems             []string               `protobuf:"bytes,15,rep,name=problems,proto3" json:"problems,omitempty"`                                                        // alasan jika intact/trusted false, atau catatan lain
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SignatureVerification) Reset() {
+	*x = SignatureVerification{}
+	mi := &file_docgen_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignatureVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureVerification) ProtoMessage() {}
+
+func (x *SignatureVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureVerification.ProtoReflect.Descriptor instead.
+func (*SignatureVerification) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignatureVerification) GetFieldName() string {
+	if x != nil {
+		return x.FieldName
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetSubFilter() string {
+	if x != nil {
+		return x.SubFilter
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetSignerName() string {
+	if x != nil {
+		return x.SignerName
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetSignerSubject() string {
+	if x != nil {
+		return x.SignerSubject
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetSignerProfile() string {
+	if x != nil {
+		return x.SignerProfile
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetSigningTime() string {
+	if x != nil {
+		return x.SigningTime
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetTimestampTime() string {
+	if x != nil {
+		return x.TimestampTime
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SignatureVerification) GetIntact() bool {
+	if x != nil {
+		return x.Intact
+	}
+	return false
+}
+
+func (x *SignatureVerification) GetTrusted() bool {
+	if x != nil {
+		return x.Trusted
+	}
+	return false
+}
+
+func (x *SignatureVerification) GetModifiedAfterSigning() bool {
+	if x != nil {
+		return x.ModifiedAfterSigning
+	}
+	return false
+}
+
+func (x *SignatureVerification) GetRevisionRecorded() bool {
+	if x != nil {
+		return x.RevisionRecorded
+	}
+	return false
+}
+
+func (x *SignatureVerification) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+// Satu panggilan GeneratePDF/GenerateDocx/Generate yang tercatat di audit trail.
+type AuditEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time           string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"` // RFC 3339 (UTC), saat request diterima
+	DurationMs     int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Method         string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"` // mis. /docgen.DocService/GeneratePDF
+	Caller         string                 `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"` // identitas caller, mis. key:<id>, jwt:<sub>, cert:<nama>
+	TemplateSha256 string                 `protobuf:"bytes,6,opt,name=template_sha256,json=templateSha256,proto3" json:"template_sha256,omitempty"`
+	DataSha256     string                 `protobuf:"bytes,7,opt,name=data_sha256,json=dataSha256,proto3" json:"data_sha256,omitempty"`                                             // hash JSON data (key terurut)
+	Data           map[string]string      `protobuf:"bytes,8,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // hanya jika server menyimpan data; field sensitif "[REDACTED]"
+	OutputSha256   string                 `protobuf:"bytes,9,opt,name=output_sha256,json=outputSha256,proto3" json:"output_sha256,omitempty"`                                       // kosong jika gagal
+	ContentType    string                 `protobuf:"bytes,10,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SignerProfile  string                 `protobuf:"bytes,11,opt,name=signer_profile,json=signerProfile,proto3" json:"signer_profile,omitempty"`
+	Code           string                 `protobuf:"bytes,12,opt,name=code,proto3" json:"code,omitempty"` // kode gRPC, mis. OK, InvalidArgument
+	Error          string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	Tenant         string                 `protobuf:"bytes,14,opt,name=tenant,proto3" json:"tenant,omitempty"` // tenant caller, kosong untuk caller global
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_docgen_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AuditEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTemplateSha256() string {
+	if x != nil {
+		return x.TemplateSha256
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDataSha256() string {
+	if x != nil {
+		return x.DataSha256
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetOutputSha256() string {
+	if x != nil {
+		return x.OutputSha256
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetSignerProfile() string {
+	if x != nil {
+		return x.SignerProfile
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type AuditQuery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OutputSha256   string                 `protobuf:"bytes,1,opt,name=output_sha256,json=outputSha256,proto3" json:"output_sha256,omitempty"`
+	Caller         string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	TemplateSha256 string                 `protobuf:"bytes,3,opt,name=template_sha256,json=templateSha256,proto3" json:"template_sha256,omitempty"`
+	Since          string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`   // RFC 3339, inklusif
+	Until          string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`   // RFC 3339, eksklusif
+	Limit          int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`  // default 100, maksimum 1000; entri terbaru lebih dulu
+	Tenant         string                 `protobuf:"bytes,7,opt,name=tenant,proto3" json:"tenant,omitempty"` // caller dengan tenant selalu dibatasi ke tenant-nya sendiri
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditQuery) Reset() {
+	*x = AuditQuery{}
+	mi := &file_docgen_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditQuery) ProtoMessage() {}
+
+func (x *AuditQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditQuery.ProtoReflect.Descriptor instead.
+func (*AuditQuery) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AuditQuery) GetOutputSha256() string {
+	if x != nil {
+		return x.OutputSha256
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetTemplateSha256() string {
+	if x != nil {
+		return x.TemplateSha256
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AuditQuery) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type AuditQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditQueryResponse) Reset() {
+	*x = AuditQueryResponse{}
+	mi := &file_docgen_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditQueryResponse) ProtoMessage() {}
+
+func (x *AuditQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditQueryResponse.ProtoReflect.Descriptor instead.
+func (*AuditQueryResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AuditQueryResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type LookupDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // tanpa tanda "-" dan huruf kecil juga diterima
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupDocumentRequest) Reset() {
+	*x = LookupDocumentRequest{}
+	mi := &file_docgen_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupDocumentRequest) ProtoMessage() {}
+
+func (x *LookupDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupDocumentRequest.ProtoReflect.Descriptor instead.
+func (*LookupDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LookupDocumentRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DocumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Sha256        string                 `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"` // hash output yang diberikan ke pemanggil
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`
+	SignerProfile string                 `protobuf:"bytes,6,opt,name=signer_profile,json=signerProfile,proto3" json:"signer_profile,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	VerifyUrl     string                 `protobuf:"bytes,8,opt,name=verify_url,json=verifyUrl,proto3" json:"verify_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInfo) Reset() {
+	*x = DocumentInfo{}
+	mi := &file_docgen_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInfo) ProtoMessage() {}
+
+func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
+func (*DocumentInfo) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DocumentInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DocumentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetSignerProfile() string {
+	if x != nil {
+		return x.SignerProfile
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetVerifyUrl() string {
+	if x != nil {
+		return x.VerifyUrl
+	}
+	return ""
+}
+
+type PreviewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*PreviewRequest_Template
+	//	*PreviewRequest_Generate
+	Source        isPreviewRequest_Source `protobuf_oneof:"source"`
+	Pages         string                  `protobuf:"bytes,3,opt,name=pages,proto3" json:"pages,omitempty"`                                                          // seleksi halaman seperti watermark, mis. "1-3,5"; default "1"
+	Dpi           int32                   `protobuf:"varint,4,opt,name=dpi,proto3" json:"dpi,omitempty"`                                                             // default 96, maks 300; diabaikan jika width diisi
+	Width         int32                   `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`                                                         // >0: lebar gambar dalam pixel, tinggi mengikuti
+	ImageFormat   OutputFormat            `protobuf:"varint,6,opt,name=image_format,json=imageFormat,proto3,enum=docgen.OutputFormat" json:"image_format,omitempty"` // OUTPUT_FORMAT_PNG (default) atau OUTPUT_FORMAT_JPEG
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRequest) Reset() {
+	*x = PreviewRequest{}
+	mi := &file_docgen_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRequest) ProtoMessage() {}
+
+func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PreviewRequest) GetSource() isPreviewRequest_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PreviewRequest) GetTemplate() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*PreviewRequest_Template); ok {
+			return x.Template
+		}
+	}
+	return nil
+}
+
+func (x *PreviewRequest) GetGenerate() *GenerateRequest {
+	if x != nil {
+		if x, ok := x.Source.(*PreviewRequest_Generate); ok {
+			return x.Generate
+		}
+	}
+	return nil
+}
+
+func (x *PreviewRequest) GetPages() string {
+	if x != nil {
+		return x.Pages
+	}
+	return ""
+}
+
+func (x *PreviewRequest) GetDpi() int32 {
+	if x != nil {
+		return x.Dpi
+	}
+	return 0
+}
+
+func (x *PreviewRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *PreviewRequest) GetImageFormat() OutputFormat {
+	if x != nil {
+		return x.ImageFormat
+	}
+	return OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+}
+
+type isPreviewRequest_Source interface {
+	isPreviewRequest_Source()
+}
+
+type PreviewRequest_Template struct {
+	Template []byte `protobuf:"bytes,1,opt,name=template,proto3,oneof"` // template mentah, placeholder diberi highlight kuning
+}
+
+type PreviewRequest_Generate struct {
+	Generate *GenerateRequest `protobuf:"bytes,2,opt,name=generate,proto3,oneof"` // dokumen terisi, dirender sebagai PDF tanpa security/signature/verification
+}
+
+func (*PreviewRequest_Template) isPreviewRequest_Source() {}
+
+func (*PreviewRequest_Generate) isPreviewRequest_Source() {}
+
+type PreviewPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"` // nomor halaman, mulai dari 1
+	Image         []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"` // pixel
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewPage) Reset() {
+	*x = PreviewPage{}
+	mi := &file_docgen_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewPage) ProtoMessage() {}
+
+func (x *PreviewPage) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewPage.ProtoReflect.Descriptor instead.
+func (*PreviewPage) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PreviewPage) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PreviewPage) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *PreviewPage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *PreviewPage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *PreviewPage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type PreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         []*PreviewPage         `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	PageCount     int32                  `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"` // jumlah halaman dokumen
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewResponse) Reset() {
+	*x = PreviewResponse{}
+	mi := &file_docgen_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewResponse) ProtoMessage() {}
+
+func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewResponse.ProtoReflect.Descriptor instead.
+func (*PreviewResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PreviewResponse) GetPages() []*PreviewPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *PreviewResponse) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+type TemplateDiagnostic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      DiagnosticSeverity     `protobuf:"varint,1,opt,name=severity,proto3,enum=docgen.DiagnosticSeverity" json:"severity,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // stabil untuk dipakai UI, mis. "unclosed_placeholder", "missing_font"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Part          string                 `protobuf:"bytes,4,opt,name=part,proto3" json:"part,omitempty"`            // mis. word/document.xml, word/header1.xml
+	Paragraph     int32                  `protobuf:"varint,5,opt,name=paragraph,proto3" json:"paragraph,omitempty"` // nomor paragraf dalam part, mulai dari 1; 0 jika tidak berlaku
+	Snippet       string                 `protobuf:"bytes,6,opt,name=snippet,proto3" json:"snippet,omitempty"`      // potongan teks di sekitar masalah
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateDiagnostic) Reset() {
+	*x = TemplateDiagnostic{}
+	mi := &file_docgen_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateDiagnostic) ProtoMessage() {}
+
+func (x *TemplateDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateDiagnostic.ProtoReflect.Descriptor instead.
+func (*TemplateDiagnostic) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TemplateDiagnostic) GetSeverity() DiagnosticSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return DiagnosticSeverity_SEVERITY_UNSPECIFIED
+}
+
+func (x *TemplateDiagnostic) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TemplateDiagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TemplateDiagnostic) GetPart() string {
+	if x != nil {
+		return x.Part
+	}
+	return ""
+}
+
+func (x *TemplateDiagnostic) GetParagraph() int32 {
+	if x != nil {
+		return x.Paragraph
+	}
+	return 0
+}
+
+func (x *TemplateDiagnostic) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type ValidateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`            // tidak ada diagnostic SEVERITY_ERROR
+	Diagnostics   []*TemplateDiagnostic  `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"` // error dulu, lalu warning, lalu info
+	Placeholders  []string               `protobuf:"bytes,3,rep,name=placeholders,proto3" json:"placeholders,omitempty"`
+	Fonts         []string               `protobuf:"bytes,4,rep,name=fonts,proto3" json:"fonts,omitempty"` // font yang dipakai template
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTemplateResponse) Reset() {
+	*x = ValidateTemplateResponse{}
+	mi := &file_docgen_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTemplateResponse) ProtoMessage() {}
+
+func (x *ValidateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ValidateTemplateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateTemplateResponse) GetDiagnostics() []*TemplateDiagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *ValidateTemplateResponse) GetPlaceholders() []string {
+	if x != nil {
+		return x.Placeholders
+	}
+	return nil
+}
+
+func (x *ValidateTemplateResponse) GetFonts() []string {
+	if x != nil {
+		return x.Fonts
+	}
+	return nil
+}
+
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"` // orang/tim yang bertanggung jawab
+	Tenant        string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Scopes        []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`                        // mis. "generate", "admin"; "*" = semua
+	ExpiresAt     string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339; kosong = tidak kedaluwarsa
+	Disabled      bool                   `protobuf:"varint,7,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	RotatedAt     string                 `protobuf:"bytes,9,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"` // RFC 3339, rotasi terakhir
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_docgen_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *APIKey) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *APIKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetRotatedAt() string {
+	if x != nil {
+		return x.RotatedAt
+	}
+	return ""
+}
+
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339, opsional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_docgen_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type RotateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339; kosong = tidak diubah
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateAPIKeyRequest) Reset() {
+	*x = RotateAPIKeyRequest{}
+	mi := &file_docgen_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateAPIKeyRequest) ProtoMessage() {}
+
+func (x *RotateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RotateAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RotateAPIKeyRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_docgen_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RevokeAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // filter, opsional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_docgen_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAPIKeysRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*APIKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_docgen_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListAPIKeysResponse) GetKeys() []*APIKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type APIKeySecret struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *APIKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // plaintext, tidak bisa diambil lagi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeySecret) Reset() {
+	*x = APIKeySecret{}
+	mi := &file_docgen_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeySecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeySecret) ProtoMessage() {}
+
+func (x *APIKeySecret) ProtoReflect() protoreflect.Message {
+	mi := &file_docgen_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeySecret.ProtoReflect.Descriptor instead.
+func (*APIKeySecret) Descriptor() ([]byte, []int) {
+	return file_docgen_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *APIKeySecret) GetKey() *APIKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *APIKeySecret) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+var File_docgen_proto protoreflect.FileDescriptor
+
+var file_docgen_proto_rawDesc = string([]byte{
+	0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x22, 0x2d, 0x0a, 0x0f, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x39, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73,
+	0x22, 0xc4, 0x04, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x35, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x79, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x62, 0x79, 0x70, 0x61, 0x73, 0x73, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x03, 0x70, 0x64, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x64, 0x66, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x03, 0x70, 0x64, 0x66, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x6f,
+	0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x64, 0x66, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2f, 0x0a, 0x09, 0x77, 0x61,
+	0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b,
+	0x52, 0x09, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x32, 0x0a, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x64, 0x66, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x3d, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x37,
+	0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8e, 0x01, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6c, 0x6c,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x6c,
+	0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67,
+	0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0xda, 0x01, 0x0a, 0x0c, 0x50, 0x64, 0x66,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3b, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x65,
+	0x61, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64,
+	0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x41,
+	0x70, 0x70, 0x65, 0x61, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x65, 0x61,
+	0x72, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x13, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x41, 0x70, 0x70, 0x65, 0x61, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22,
+	0xc6, 0x01, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x6e, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x6f, 0x6e, 0x74,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6f, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xaa, 0x01, 0x0a, 0x0b, 0x50, 0x64, 0x66,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x75, 0x73, 0x65, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x5f, 0x63, 0x6f, 0x70, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6e, 0x6f, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x5f, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x22, 0xc3, 0x03, 0x0a, 0x0a, 0x50, 0x64, 0x66, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x64, 0x66, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x64, 0x66, 0x41,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x70, 0x64, 0x66, 0x61, 0x12, 0x1b, 0x0a, 0x06, 0x74,
+	0x61, 0x67, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x74,
+	0x61, 0x67, 0x67, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x65, 0x6d, 0x62, 0x65,
+	0x64, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x5f, 0x66, 0x6f, 0x6e, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x12, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x46, 0x6f, 0x6e, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x21, 0x0a, 0x0c, 0x6a, 0x70, 0x65, 0x67, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6a, 0x70, 0x65, 0x67, 0x51, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x2c, 0x0a, 0x0f, 0x6c, 0x6f, 0x73, 0x73, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x02, 0x52, 0x0e, 0x6c,
+	0x6f, 0x73, 0x73, 0x6c, 0x65, 0x73, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x70,
+	0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x44, 0x70, 0x69, 0x12, 0x2e, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x03,
+	0x52, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x04, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x61, 0x67, 0x67,
+	0x65, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61,
+	0x6e, 0x64, 0x61, 0x72, 0x64, 0x5f, 0x66, 0x6f, 0x6e, 0x74, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f,
+	0x6c, 0x6f, 0x73, 0x73, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x6d,
+	0x61, 0x72, 0x6b, 0x73, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x10,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x33,
+	0x0a, 0x09, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x53, 0x61, 0x6e, 0x69, 0x74,
+	0x69, 0x7a, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69,
+	0x7a, 0x65, 0x64, 0x22, 0x67, 0x0a, 0x0d, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x64,
+	0x49, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x8e, 0x01, 0x0a,
+	0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x70, 0x64, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x00, 0x52, 0x03, 0x70, 0x64, 0x66, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x78, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xde, 0x01,
+	0x0a, 0x0c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d,
+	0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x48,
+	0x69, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63,
+	0x67, 0x65, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x24,
+	0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x44, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x64, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x70, 0x64, 0x66, 0x22, 0xe2, 0x01, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50,
+	0x44, 0x46, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68,
+	0x61, 0x32, 0x35, 0x36, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x3d, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x8b, 0x04, 0x0a, 0x15, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x72, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e,
+	0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x61, 0x63, 0x74,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2b,
+	0x0a, 0x11, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x22, 0xe7, 0x03, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x74,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x53, 0x68,
+	0x61, 0x32, 0x35, 0x36, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x53,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xcc, 0x01, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35,
+	0x36, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x27, 0x0a,
+	0x0f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x6e, 0x74,
+	0x69, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x22, 0x42, 0x0a, 0x12, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e,
+	0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x2b, 0x0a, 0x15, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x22, 0xf2, 0x01, 0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x55, 0x72, 0x6c, 0x22, 0xe6, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x08, 0x74,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67,
+	0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x70, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x64, 0x70, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x37, 0x0a, 0x0c,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
+	0x88, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x5b, 0x0a, 0x0f, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64,
+	0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x50, 0x61, 0x67,
+	0x65, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xc6, 0x01, 0x0a, 0x12, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x36,
+	0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1a, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72,
+	0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x22, 0xa8, 0x01, 0x0a, 0x18, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65,
+	0x6e, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x6e, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6f, 0x6e, 0x74, 0x73, 0x22, 0xeb, 0x01, 0x0a, 0x06,
+	0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x13, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x44, 0x0a, 0x13, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x22, 0x25, 0x0a, 0x13, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2c, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x39, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49,
+	0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x6f, 0x63,
+	0x67, 0x65, 0x6e, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x22, 0x48, 0x0a, 0x0c, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x12, 0x20, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2a, 0xe8, 0x01, 0x0a, 0x0c, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x4f,
+	0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55,
+	0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x50, 0x44, 0x46, 0x10,
+	0x01, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d,
+	0x41, 0x54, 0x5f, 0x44, 0x4f, 0x43, 0x58, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54,
+	0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4f, 0x44, 0x54, 0x10, 0x03,
+	0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x52, 0x54, 0x46, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x50, 0x55,
+	0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x48, 0x54, 0x4d, 0x4c, 0x10, 0x05, 0x12,
+	0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54,
+	0x5f, 0x54, 0x58, 0x54, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x50, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x16, 0x0a,
+	0x12, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4a,
+	0x50, 0x45, 0x47, 0x10, 0x08, 0x2a, 0x41, 0x0a, 0x09, 0x50, 0x64, 0x66, 0x41, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x44, 0x46, 0x41, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x44, 0x46, 0x41, 0x5f, 0x31, 0x42, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x50, 0x44, 0x46, 0x41, 0x5f, 0x32, 0x42, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x50,
+	0x44, 0x46, 0x41, 0x5f, 0x33, 0x42, 0x10, 0x03, 0x2a, 0x6b, 0x0a, 0x12, 0x44, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18,
+	0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x56, 0x45,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
+	0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x49,
+	0x4e, 0x46, 0x4f, 0x10, 0x03, 0x32, 0xb3, 0x05, 0x0a, 0x0a, 0x44, 0x6f, 0x63, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65,
+	0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e,
+	0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a,
+	0x0b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x44, 0x46, 0x12, 0x17, 0x2e, 0x64,
+	0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x41, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x63, 0x78, 0x12,
+	0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65,
+	0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x17,
+	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e,
+	0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x40, 0x0a, 0x0e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67,
+	0x65, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x44, 0x46,
+	0x12, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x50, 0x44, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6f, 0x63,
+	0x67, 0x65, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x44, 0x46, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1a, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e,
+	0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x40, 0x0a, 0x0d, 0x52, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x16, 0x2e, 0x64, 0x6f,
+	0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x10,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x6f, 0x63, 0x67,
+	0x65, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x95, 0x02, 0x0a, 0x08,
+	0x4b, 0x65, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x41, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65,
+	0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x41,
+	0x50, 0x49, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x0c, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x2e, 0x64, 0x6f,
+	0x63, 0x67, 0x65, 0x6e, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65,
+	0x6e, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x3b,
+	0x0a, 0x0c, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x1b,
+	0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x50,
+	0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x64, 0x6f,
+	0x63, 0x67, 0x65, 0x6e, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x46, 0x0a, 0x0b, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1a, 0x2e, 0x64, 0x6f, 0x63,
+	0x67, 0x65, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x64, 0x65, 0x64, 0x69, 0x6e, 0x69, 0x72, 0x74, 0x61, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x61, 0x2f, 0x64, 0x6f, 0x63, 0x78, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x67, 0x65,
+	0x6e, 0x70, 0x62, 0x3b, 0x64, 0x6f, 0x63, 0x67, 0x65, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_docgen_proto_rawDescOnce sync.Once
+	file_docgen_proto_rawDescData []byte
+)
+
+func file_docgen_proto_rawDescGZIP() []byte {
+	file_docgen_proto_rawDescOnce.Do(func() {
+		file_docgen_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_docgen_proto_rawDesc), len(file_docgen_proto_rawDesc)))
+	})
+	return file_docgen_proto_rawDescData
+}
+
+var file_docgen_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_docgen_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_docgen_proto_goTypes = []any{
+	(OutputFormat)(0),                // 0: docgen.OutputFormat
+	(PdfALevel)(0),                   // 1: docgen.PdfALevel
+	(DiagnosticSeverity)(0),          // 2: docgen.DiagnosticSeverity
+	(*TemplateRequest)(nil),          // 3: docgen.TemplateRequest
+	(*PlaceholderResponse)(nil),      // 4: docgen.PlaceholderResponse
+	(*GenerateRequest)(nil),          // 5: docgen.GenerateRequest
+	(*VerificationStamp)(nil),        // 6: docgen.VerificationStamp
+	(*PdfSignature)(nil),             // 7: docgen.PdfSignature
+	(*SignatureAppearance)(nil),      // 8: docgen.SignatureAppearance
+	(*Watermark)(nil),                // 9: docgen.Watermark
+	(*PdfSecurity)(nil),              // 10: docgen.PdfSecurity
+	(*PdfOptions)(nil),               // 11: docgen.PdfOptions
+	(*GenerateResponse)(nil),         // 12: docgen.GenerateResponse
+	(*SanitizedItem)(nil),            // 13: docgen.SanitizedItem
+	(*MergeSource)(nil),              // 14: docgen.MergeSource
+	(*MergeRequest)(nil),             // 15: docgen.MergeRequest
+	(*VerifyPDFRequest)(nil),         // 16: docgen.VerifyPDFRequest
+	(*VerifyPDFResponse)(nil),        // 17: docgen.VerifyPDFResponse
+	(*SignatureVerification)(nil),    // 18: docgen.SignatureVerification
+	(*AuditEntry)(nil),               // 19: docgen.AuditEntry
+	(*AuditQuery)(nil),               // 20: docgen.AuditQuery
+	(*AuditQueryResponse)(nil),       // 21: docgen.AuditQueryResponse
+	(*LookupDocumentRequest)(nil),    // 22: docgen.LookupDocumentRequest
+	(*DocumentInfo)(nil),             // 23: docgen.DocumentInfo
+	(*PreviewRequest)(nil),           // 24: docgen.PreviewRequest
+	(*PreviewPage)(nil),              // 25: docgen.PreviewPage
+	(*PreviewResponse)(nil),          // 26: docgen.PreviewResponse
+	(*TemplateDiagnostic)(nil),       // 27: docgen.TemplateDiagnostic
+	(*ValidateTemplateResponse)(nil), // 28: docgen.ValidateTemplateResponse
+	(*APIKey)(nil),                   // 29: docgen.APIKey
+	(*CreateAPIKeyRequest)(nil),      // 30: docgen.CreateAPIKeyRequest
+	(*RotateAPIKeyRequest)(nil),      // 31: docgen.RotateAPIKeyRequest
+	(*RevokeAPIKeyRequest)(nil),      // 32: docgen.RevokeAPIKeyRequest
+	(*ListAPIKeysRequest)(nil),       // 33: docgen.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),      // 34: docgen.ListAPIKeysResponse
+	(*APIKeySecret)(nil),             // 35: docgen.APIKeySecret
+	nil,                              // 36: docgen.GenerateRequest.DataEntry
+	nil,                              // 37: docgen.AuditEntry.DataEntry
+}
+var file_docgen_proto_depIdxs = []int32{
+	36, // 0: docgen.GenerateRequest.data:type_name -> docgen.GenerateRequest.DataEntry
+	0,  // 1: docgen.GenerateRequest.output_format:type_name -> docgen.OutputFormat
+	11, // 2: docgen.GenerateRequest.pdf:type_name -> docgen.PdfOptions
+	10, // 3: docgen.GenerateRequest.security:type_name -> docgen.PdfSecurity
+	9,  // 4: docgen.GenerateRequest.watermark:type_name -> docgen.Watermark
+	7,  // 5: docgen.GenerateRequest.signature:type_name -> docgen.PdfSignature
+	6,  // 6: docgen.GenerateRequest.verification:type_name -> docgen.VerificationStamp
+	8,  // 7: docgen.PdfSignature.appearance:type_name -> docgen.SignatureAppearance
+	1,  // 8: docgen.PdfOptions.pdfa:type_name -> docgen.PdfALevel
+	13, // 9: docgen.GenerateResponse.sanitized:type_name -> docgen.SanitizedItem
+	5,  // 10: docgen.MergeSource.generate:type_name -> docgen.GenerateRequest
+	14, // 11: docgen.MergeRequest.sources:type_name -> docgen.MergeSource
+	0,  // 12: docgen.MergeRequest.output_format:type_name -> docgen.OutputFormat
+	18, // 13: docgen.VerifyPDFResponse.signatures:type_name -> docgen.SignatureVerification
+	37, // 14: docgen.AuditEntry.data:type_name -> docgen.AuditEntry.DataEntry
+	19, // 15: docgen.AuditQueryResponse.entries:type_name -> docgen.AuditEntry
+	5,  // 16: docgen.PreviewRequest.generate:type_name -> docgen.GenerateRequest
+	0,  // 17: docgen.PreviewRequest.image_format:type_name -> docgen.OutputFormat
+	25, // 18: docgen.PreviewResponse.pages:type_name -> docgen.PreviewPage
+	2,  // 19: docgen.TemplateDiagnostic.severity:type_name -> docgen.DiagnosticSeverity
+	27, // 20: docgen.ValidateTemplateResponse.diagnostics:type_name -> docgen.TemplateDiagnostic
+	29, // 21: docgen.ListAPIKeysResponse.keys:type_name -> docgen.APIKey
+	29, // 22: docgen.APIKeySecret.key:type_name -> docgen.APIKey
+	3,  // 23: docgen.DocService.GetPlaceholders:input_type -> docgen.TemplateRequest
+	5,  // 24: docgen.DocService.GeneratePDF:input_type -> docgen.GenerateRequest
+	5,  // 25: docgen.DocService.GenerateDocx:input_type -> docgen.GenerateRequest
+	5,  // 26: docgen.DocService.Generate:input_type -> docgen.GenerateRequest
+	15, // 27: docgen.DocService.MergeDocuments:input_type -> docgen.MergeRequest
+	16, // 28: docgen.DocService.VerifyPDF:input_type -> docgen.VerifyPDFRequest
+	20, // 29: docgen.DocService.QueryAudit:input_type -> docgen.AuditQuery
+	22, // 30: docgen.DocService.LookupDocument:input_type -> docgen.LookupDocumentRequest
+	24, // 31: docgen.DocService.RenderPreview:input_type -> docgen.PreviewRequest
+	3,  // 32: docgen.DocService.ValidateTemplate:input_type -> docgen.TemplateRequest
+	30, // 33: docgen.KeyAdmin.CreateAPIKey:input_type -> docgen.CreateAPIKeyRequest
+	31, // 34: docgen.KeyAdmin.RotateAPIKey:input_type -> docgen.RotateAPIKeyRequest
+	32, // 35: docgen.KeyAdmin.RevokeAPIKey:input_type -> docgen.RevokeAPIKeyRequest
+	33, // 36: docgen.KeyAdmin.ListAPIKeys:input_type -> docgen.ListAPIKeysRequest
+	4,  // 37: docgen.DocService.GetPlaceholders:output_type -> docgen.PlaceholderResponse
+	12, // 38: docgen.DocService.GeneratePDF:output_type -> docgen.GenerateResponse
+	12, // 39: docgen.DocService.GenerateDocx:output_type -> docgen.GenerateResponse
+	12, // 40: docgen.DocService.Generate:output_type -> docgen.GenerateResponse
+	12, // 41: docgen.DocService.MergeDocuments:output_type -> docgen.GenerateResponse
+	17, // 42: docgen.DocService.VerifyPDF:output_type -> docgen.VerifyPDFResponse
+	21, // 43: docgen.DocService.QueryAudit:output_type -> docgen.AuditQueryResponse
+	23, // 44: docgen.DocService.LookupDocument:output_type -> docgen.DocumentInfo
+	26, // 45: docgen.DocService.RenderPreview:output_type -> docgen.PreviewResponse
+	28, // 46: docgen.DocService.ValidateTemplate:output_type -> docgen.ValidateTemplateResponse
+	35, // 47: docgen.KeyAdmin.CreateAPIKey:output_type -> docgen.APIKeySecret
+	35, // 48: docgen.KeyAdmin.RotateAPIKey:output_type -> docgen.APIKeySecret
+	29, // 49: docgen.KeyAdmin.RevokeAPIKey:output_type -> docgen.APIKey
+	34, // 50: docgen.KeyAdmin.ListAPIKeys:output_type -> docgen.ListAPIKeysResponse
+	37, // [37:51] is the sub-list for method output_type
+	23, // [23:37] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_docgen_proto_init() }
+func file_docgen_proto_init() {
+	if File_docgen_proto != nil {
+		return
+	}
+	file_docgen_proto_msgTypes[6].OneofWrappers = []any{}
+	file_docgen_proto_msgTypes[8].OneofWrappers = []any{}
+	file_docgen_proto_msgTypes[11].OneofWrappers = []any{
+		(*MergeSource_Generate)(nil),
+		(*MergeSource_Pdf)(nil),
+		(*MergeSource_Docx)(nil),
+	}
+	file_docgen_proto_msgTypes[21].OneofWrappers = []any{
+		(*PreviewRequest_Template)(nil),
+		(*PreviewRequest_Generate)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_docgen_proto_rawDesc), len(file_docgen_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   35,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_docgen_proto_goTypes,
 		DependencyIndexes: file_docgen_proto_depIdxs,
+		EnumInfos:         file_docgen_proto_enumTypes,
 		MessageInfos:      file_docgen_proto_msgTypes,
 	}.Build()
 	File_docgen_proto = out.File
@@ -19,9 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DocService_GetPlaceholders_FullMethodName = "/docgen.DocService/GetPlaceholders"
-	DocService_GeneratePDF_FullMethodName     = "/docgen.DocService/GeneratePDF"
-	DocService_GenerateDocx_FullMethodName    = "/docgen.DocService/GenerateDocx"
+	DocService_GetPlaceholders_FullMethodName  = "/docgen.DocService/GetPlaceholders"
+	DocService_GeneratePDF_FullMethodName      = "/docgen.DocService/GeneratePDF"
+	DocService_GenerateDocx_FullMethodName     = "/docgen.DocService/GenerateDocx"
+	DocService_Generate_FullMethodName         = "/docgen.DocService/Generate"
+	DocService_MergeDocuments_FullMethodName   = "/docgen.DocService/MergeDocuments"
+	DocService_VerifyPDF_FullMethodName        = "/docgen.DocService/VerifyPDF"
+	DocService_QueryAudit_FullMethodName       = "/docgen.DocService/QueryAudit"
+	DocService_LookupDocument_FullMethodName   = "/docgen.DocService/LookupDocument"
+	DocService_RenderPreview_FullMethodName    = "/docgen.DocService/RenderPreview"
+	DocService_ValidateTemplate_FullMethodName = "/docgen.DocService/ValidateTemplate"
 )
 
 // DocServiceClient is the client API for DocService service.
@@ -34,6 +41,20 @@ type DocServiceClient interface {
 	GeneratePDF(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
 	// (opsional) hanya hasil DOCX
 	GenerateDocx(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// Generate ke format apa pun sesuai GenerateRequest.output_format
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// Gabungkan beberapa dokumen (template+data, PDF, DOCX) jadi satu PDF
+	MergeDocuments(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// Periksa tanda tangan PDF dan apakah dokumen tercatat dihasilkan service ini
+	VerifyPDF(ctx context.Context, in *VerifyPDFRequest, opts ...grpc.CallOption) (*VerifyPDFResponse, error)
+	// Cari audit trail generate berdasarkan hash output, caller atau template
+	QueryAudit(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (*AuditQueryResponse, error)
+	// Metadata dokumen berdasarkan kode verifikasi (dari QR / teks di dokumen)
+	LookupDocument(ctx context.Context, in *LookupDocumentRequest, opts ...grpc.CallOption) (*DocumentInfo, error)
+	// Gambar halaman (PNG/JPEG) dari template mentah atau dokumen terisi, untuk preview di UI
+	RenderPreview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
+	// Periksa template sebelum dipakai: paket DOCX, sintaks placeholder, font, macro, link eksternal
+	ValidateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*ValidateTemplateResponse, error)
 }
 
 type docServiceClient struct {
@@ -74,6 +95,76 @@ func (c *docServiceClient) GenerateDocx(ctx context.Context, in *GenerateRequest
 	return out, nil
 }
 
+func (c *docServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, DocService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) MergeDocuments(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, DocService_MergeDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) VerifyPDF(ctx context.Context, in *VerifyPDFRequest, opts ...grpc.CallOption) (*VerifyPDFResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPDFResponse)
+	err := c.cc.Invoke(ctx, DocService_VerifyPDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) QueryAudit(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (*AuditQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditQueryResponse)
+	err := c.cc.Invoke(ctx, DocService_QueryAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) LookupDocument(ctx context.Context, in *LookupDocumentRequest, opts ...grpc.CallOption) (*DocumentInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentInfo)
+	err := c.cc.Invoke(ctx, DocService_LookupDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) RenderPreview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, DocService_RenderPreview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) ValidateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*ValidateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateTemplateResponse)
+	err := c.cc.Invoke(ctx, DocService_ValidateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocServiceServer is the server API for DocService service.
 // All implementations must embed UnimplementedDocServiceServer
 // for forward compatibility.
@@ -84,6 +175,20 @@ type DocServiceServer interface {
 	GeneratePDF(context.Context, *GenerateRequest) (*GenerateResponse, error)
 	// (opsional) hanya hasil DOCX
 	GenerateDocx(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// Generate ke format apa pun sesuai GenerateRequest.output_format
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// Gabungkan beberapa dokumen (template+data, PDF, DOCX) jadi satu PDF
+	MergeDocuments(context.Context, *MergeRequest) (*GenerateResponse, error)
+	// Periksa tanda tangan PDF dan apakah dokumen tercatat dihasilkan service ini
+	VerifyPDF(context.Context, *VerifyPDFRequest) (*VerifyPDFResponse, error)
+	// Cari audit trail generate berdasarkan hash output, caller atau template
+	QueryAudit(context.Context, *AuditQuery) (*AuditQueryResponse, error)
+	// Metadata dokumen berdasarkan kode verifikasi (dari QR / teks di dokumen)
+	LookupDocument(context.Context, *LookupDocumentRequest) (*DocumentInfo, error)
+	// Gambar halaman (PNG/JPEG) dari template mentah atau dokumen terisi, untuk preview di UI
+	RenderPreview(context.Context, *PreviewRequest) (*PreviewResponse, error)
+	// Periksa template sebelum dipakai: paket DOCX, sintaks placeholder, font, macro, link eksternal
+	ValidateTemplate(context.Context, *TemplateRequest) (*ValidateTemplateResponse, error)
 	mustEmbedUnimplementedDocServiceServer()
 }
 
@@ -103,6 +208,27 @@ func (UnimplementedDocServiceServer) GeneratePDF(context.Context, *GenerateReque
 func (UnimplementedDocServiceServer) GenerateDocx(context.Context, *GenerateRequest) (*GenerateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateDocx not implemented")
 }
+func (UnimplementedDocServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedDocServiceServer) MergeDocuments(context.Context, *MergeRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeDocuments not implemented")
+}
+func (UnimplementedDocServiceServer) VerifyPDF(context.Context, *VerifyPDFRequest) (*VerifyPDFResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPDF not implemented")
+}
+func (UnimplementedDocServiceServer) QueryAudit(context.Context, *AuditQuery) (*AuditQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAudit not implemented")
+}
+func (UnimplementedDocServiceServer) LookupDocument(context.Context, *LookupDocumentRequest) (*DocumentInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupDocument not implemented")
+}
+func (UnimplementedDocServiceServer) RenderPreview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderPreview not implemented")
+}
+func (UnimplementedDocServiceServer) ValidateTemplate(context.Context, *TemplateRequest) (*ValidateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTemplate not implemented")
+}
 func (UnimplementedDocServiceServer) mustEmbedUnimplementedDocServiceServer() {}
 func (UnimplementedDocServiceServer) testEmbeddedByValue()                    {}
 
@@ -178,6 +304,132 @@ func _DocService_GenerateDocx_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_MergeDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).MergeDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_MergeDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).MergeDocuments(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_VerifyPDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).VerifyPDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_VerifyPDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).VerifyPDF(ctx, req.(*VerifyPDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_QueryAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).QueryAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_QueryAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).QueryAudit(ctx, req.(*AuditQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_LookupDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).LookupDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_LookupDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).LookupDocument(ctx, req.(*LookupDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_RenderPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).RenderPreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_RenderPreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).RenderPreview(ctx, req.(*PreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_ValidateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).ValidateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_ValidateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).ValidateTemplate(ctx, req.(*TemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DocService_ServiceDesc is the grpc.ServiceDesc for DocService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,6 +449,262 @@ var DocService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateDocx",
 			Handler:    _DocService_GenerateDocx_Handler,
 		},
+		{
+			MethodName: "Generate",
+			Handler:    _DocService_Generate_Handler,
+		},
+		{
+			MethodName: "MergeDocuments",
+			Handler:    _DocService_MergeDocuments_Handler,
+		},
+		{
+			MethodName: "VerifyPDF",
+			Handler:    _DocService_VerifyPDF_Handler,
+		},
+		{
+			MethodName: "QueryAudit",
+			Handler:    _DocService_QueryAudit_Handler,
+		},
+		{
+			MethodName: "LookupDocument",
+			Handler:    _DocService_LookupDocument_Handler,
+		},
+		{
+			MethodName: "RenderPreview",
+			Handler:    _DocService_RenderPreview_Handler,
+		},
+		{
+			MethodName: "ValidateTemplate",
+			Handler:    _DocService_ValidateTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "docgen.proto",
+}
+
+const (
+	KeyAdmin_CreateAPIKey_FullMethodName = "/docgen.KeyAdmin/CreateAPIKey"
+	KeyAdmin_RotateAPIKey_FullMethodName = "/docgen.KeyAdmin/RotateAPIKey"
+	KeyAdmin_RevokeAPIKey_FullMethodName = "/docgen.KeyAdmin/RevokeAPIKey"
+	KeyAdmin_ListAPIKeys_FullMethodName  = "/docgen.KeyAdmin/ListAPIKeys"
+)
+
+// KeyAdminClient is the client API for KeyAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Kelola API key; hanya untuk key dengan scope "admin".
+type KeyAdminClient interface {
+	// Buat key baru; secret hanya dikembalikan sekali ini
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeySecret, error)
+	// Ganti secret key (id, scope dan metadata tetap); secret lama langsung tidak berlaku
+	RotateAPIKey(ctx context.Context, in *RotateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeySecret, error)
+	// Nonaktifkan key; record tetap disimpan untuk audit
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error)
+	// Daftar key tanpa secret/hash
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+}
+
+type keyAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyAdminClient(cc grpc.ClientConnInterface) KeyAdminClient {
+	return &keyAdminClient{cc}
+}
+
+func (c *keyAdminClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeySecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeySecret)
+	err := c.cc.Invoke(ctx, KeyAdmin_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyAdminClient) RotateAPIKey(ctx context.Context, in *RotateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeySecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeySecret)
+	err := c.cc.Invoke(ctx, KeyAdmin_RotateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyAdminClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, KeyAdmin_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyAdminClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, KeyAdmin_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeyAdminServer is the server API for KeyAdmin service.
+// All implementations must embed UnimplementedKeyAdminServer
+// for forward compatibility.
+//
+// Kelola API key; hanya untuk key dengan scope "admin".
+type KeyAdminServer interface {
+	// Buat key baru; secret hanya dikembalikan sekali ini
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKeySecret, error)
+	// Ganti secret key (id, scope dan metadata tetap); secret lama langsung tidak berlaku
+	RotateAPIKey(context.Context, *RotateAPIKeyRequest) (*APIKeySecret, error)
+	// Nonaktifkan key; record tetap disimpan untuk audit
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*APIKey, error)
+	// Daftar key tanpa secret/hash
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	mustEmbedUnimplementedKeyAdminServer()
+}
+
+// UnimplementedKeyAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeyAdminServer struct{}
+
+func (UnimplementedKeyAdminServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKeySecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedKeyAdminServer) RotateAPIKey(context.Context, *RotateAPIKeyRequest) (*APIKeySecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateAPIKey not implemented")
+}
+func (UnimplementedKeyAdminServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedKeyAdminServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedKeyAdminServer) mustEmbedUnimplementedKeyAdminServer() {}
+func (UnimplementedKeyAdminServer) testEmbeddedByValue()                  {}
+
+// UnsafeKeyAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyAdminServer will
+// result in compilation errors.
+type UnsafeKeyAdminServer interface {
+	mustEmbedUnimplementedKeyAdminServer()
+}
+
+func RegisterKeyAdminServer(s grpc.ServiceRegistrar, srv KeyAdminServer) {
+	// If the following call pancis, it indicates UnimplementedKeyAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KeyAdmin_ServiceDesc, srv)
+}
+
+func _KeyAdmin_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyAdminServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyAdmin_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyAdminServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyAdmin_RotateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyAdminServer).RotateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyAdmin_RotateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyAdminServer).RotateAPIKey(ctx, req.(*RotateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyAdmin_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyAdminServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyAdmin_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyAdminServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyAdmin_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyAdminServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyAdmin_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyAdminServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeyAdmin_ServiceDesc is the grpc.ServiceDesc for KeyAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeyAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "docgen.KeyAdmin",
+	HandlerType: (*KeyAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _KeyAdmin_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "RotateAPIKey",
+			Handler:    _KeyAdmin_RotateAPIKey_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _KeyAdmin_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _KeyAdmin_ListAPIKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "docgen.proto",
@@ -0,0 +1,26 @@
+#!/bin/sh
+# HEALTHCHECK container: grpc_health_probe dengan flag TLS dari env yang sama
+# dengan server. Jika TLS diatur lewat file config (bukan env), set
+# DOCGEN_HEALTH_TLS=true; untuk client_auth require set juga
+# DOCGEN_HEALTH_CLIENT_CERT dan DOCGEN_HEALTH_CLIENT_KEY (sertifikat klien dari
+# CA di client_ca_file).
+set -- -addr="${DOCGEN_GRPC_ADDR:-:5051}"
+
+if [ -n "$DOCGEN_TLS_CERT" ] || [ "$DOCGEN_HEALTH_TLS" = "true" ]; then
+  set -- "$@" -tls
+  if [ -n "$DOCGEN_HEALTH_CA" ]; then
+    set -- "$@" -tls-ca-cert="$DOCGEN_HEALTH_CA"
+    [ -n "$DOCGEN_HEALTH_SERVER_NAME" ] && set -- "$@" -tls-server-name="$DOCGEN_HEALTH_SERVER_NAME"
+  else
+    # probe ke proses sendiri lewat localhost; nama di sertifikat tidak akan cocok
+    set -- "$@" -tls-no-verify
+  fi
+  if [ -n "$DOCGEN_HEALTH_CLIENT_CERT" ]; then
+    set -- "$@" -tls-client-cert="$DOCGEN_HEALTH_CLIENT_CERT" -tls-client-key="$DOCGEN_HEALTH_CLIENT_KEY"
+  elif [ "$DOCGEN_TLS_CLIENT_AUTH" = "require" ]; then
+    echo "healthcheck: DOCGEN_TLS_CLIENT_AUTH=require needs DOCGEN_HEALTH_CLIENT_CERT and DOCGEN_HEALTH_CLIENT_KEY" >&2
+    exit 1
+  fi
+fi
+
+exec /bin/grpc_health_probe "$@"
@@ -1,55 +1,292 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"github.com/dedinirtadinata/docxtool/config"
 	"github.com/dedinirtadinata/docxtool/docgenpb"
-	"github.com/dedinirtadinata/docxtool/middleware"
 	"github.com/dedinirtadinata/docxtool/server/service"
 	"github.com/dedinirtadinata/docxtool/workerpool"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"golang.org/x/time/rate"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	cfg, printConfig, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if printConfig {
+		if err := cfg.WriteYAML(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	if printConfig {
+		return
+	}
+
 	// init logger
-	service.InitLogger()
+	if err := service.ConfigureLogger(cfg.Logging.Level, cfg.Logging.Format); err != nil {
+		log.Fatalf("logging: %v", err)
+	}
+
+	if cfg.Listen.Metrics != "" {
+		service.RegisterMetrics(cfg.Listen.Metrics)
+	}
 
-	// start /metrics on :9090
-	service.RegisterMetrics(":9090")
+	service.SetAPIKeys(cfg.Auth.APIKeys)
+	if err := service.SetMethodScopes(cfg.Auth.MethodScopes); err != nil {
+		log.Fatalf("auth.method_scopes: %v", err)
+	}
+	if err := service.SetTenants(tenants(cfg.Tenants)); err != nil {
+		log.Fatalf("tenants: %v", err)
+	}
+	if j := cfg.Auth.JWT; j.Enabled() {
+		verifier, err := service.NewJWTVerifier(service.JWTConfig{
+			Issuer:       j.Issuer,
+			Audience:     j.Audience,
+			Leeway:       j.Leeway,
+			HS256Secret:  []byte(j.HS256Secret),
+			JWKSFile:     j.JWKSFile,
+			JWKSURL:      j.JWKSURL,
+			JWKSRefresh:  j.JWKSRefresh,
+			SubjectClaim: j.Claims.Subject,
+			ScopesClaim:  j.Claims.Scopes,
+			TenantClaim:  j.Claims.Tenant,
+			NameClaim:    j.Claims.Name,
+		})
+		if err != nil {
+			log.Fatalf("jwt: %v", err)
+		}
+		service.SetJWTVerifier(verifier)
+	}
+	var keyStore *service.FileKeyStore
+	if path := cfg.Auth.KeysFile; path != "" {
+		keyStore, err = service.OpenFileKeyStore(path)
+		if err != nil {
+			log.Fatalf("open api key store: %v", err)
+		}
+		service.SetKeyStore(keyStore)
+		if cfg.Auth.WatchInterval > 0 {
+			go keyStore.Watch(cfg.Auth.WatchInterval, nil)
+		}
+	}
+	// sertifikat server dan CA klien bisa diganti tanpa restart
+	var tlsReloader *service.TLSReloader
+	if t := cfg.TLS; t.Enabled() {
+		clientAuth, err := service.ParseClientAuth(t.ClientAuth)
+		if err != nil {
+			log.Fatalf("tls: %v", err)
+		}
+		tlsReloader, err = service.NewTLSReloader(service.TLSFiles{
+			CertFile:     t.CertFile,
+			KeyFile:      t.KeyFile,
+			ClientCAFile: t.ClientCAFile,
+			ClientAuth:   clientAuth,
+		})
+		if err != nil {
+			log.Fatalf("load tls certificate: %v", err)
+		}
+		if t.ReloadInterval > 0 {
+			go tlsReloader.Watch(t.ReloadInterval, nil)
+		}
+		ids := make([]service.ClientCertIdentity, 0, len(t.ClientCerts))
+		for _, cc := range t.ClientCerts {
+			ids = append(ids, service.ClientCertIdentity{Match: cc.Match, Name: cc.Name, Tenant: cc.Tenant, Scopes: cc.Scopes})
+		}
+		service.SetClientCertIdentities(ids)
+	}
+	// SIGHUP: baca ulang key store dan sertifikat TLS
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if keyStore != nil {
+				if err := keyStore.Reload(); err != nil {
+					log.Printf("reload api keys: %v (keeping previous keys)", err)
+				} else {
+					log.Printf("api keys reloaded from %s", cfg.Auth.KeysFile)
+				}
+			}
+			if tlsReloader != nil {
+				if err := tlsReloader.Reload(); err != nil {
+					log.Printf("reload tls certificate: %v (keeping previous certificate)", err)
+				} else {
+					log.Printf("tls certificate reloaded from %s", cfg.TLS.CertFile)
+				}
+			}
+		}
+	}()
+	wp := workerpool.NewWorkerPool(cfg.Converter.Workers)
+	idempotency := service.NewIdempotencyStore(cfg.Storage.IdempotencyTTL, int64(cfg.Storage.IdempotencySize))
 
-	wp := workerpool.NewWorkerPool(5)
-	var limiter = rate.NewLimiter(2, 5) // 2 req/sec, burst 5
+	// audit trail setiap generate (JSON lines); data lengkap hanya jika storage.audit_data
+	auditStore, err := service.OpenFileAuditStore(cfg.Storage.AuditFile)
+	if err != nil {
+		log.Fatalf("open audit trail: %v", err)
+	}
+	defer auditStore.Close()
+	auditor := &service.Auditor{Store: auditStore, IncludeData: cfg.Storage.AuditData, Redact: cfg.Storage.AuditRedact}
+
+	// rate limit dan kuota dokumen per client (caller, tenant atau IP)
+	l := cfg.Limits
+	limits := service.ClientLimits{Rate: l.RateLimit, Burst: l.RateBurst, DailyDocuments: l.DailyDocuments, MonthlyDocuments: l.MonthlyDocuments}
+	clients := make(map[string]service.ClientLimits, len(l.Clients))
+	for key, o := range l.Clients {
+		c := limits
+		if o.RateLimit != nil {
+			c.Rate = *o.RateLimit
+		}
+		if o.RateBurst != nil {
+			c.Burst = *o.RateBurst
+		}
+		if o.DailyDocuments != nil {
+			c.DailyDocuments = *o.DailyDocuments
+		}
+		if o.MonthlyDocuments != nil {
+			c.MonthlyDocuments = *o.MonthlyDocuments
+		}
+		clients[key] = c
+	}
+	var usage service.UsageStore
+	if path := cfg.Storage.UsageFile; path != "" {
+		if usage, err = service.OpenFileUsageStore(path); err != nil {
+			log.Fatalf("open usage counters: %v", err)
+		}
+	}
+	limiter, err := service.NewClientLimiter(service.LimiterConfig{KeyBy: l.RateKey, Default: limits, Clients: clients, Usage: usage})
+	if err != nil {
+		log.Fatalf("limits: %v", err)
+	}
 
 	// create gRPC server with chained interceptors:
-	// order: auth -> logging -> prometheus
-	unaryChain := grpc_middleware.ChainUnaryServer(
+	// order: auth -> audit -> logging -> prometheus -> tenant metrics -> rate limit -> idempotency -> quota
+	unary := []grpc.UnaryServerInterceptor{
 		service.UnaryAuthInterceptor,
+		auditor.UnaryInterceptor,
 		service.UnaryLoggingInterceptor,
 		grpc_prometheus.UnaryServerInterceptor,
-		middleware.RateLimiter(limiter),
-	)
+		service.TenantMetricsUnaryInterceptor,
+	}
+	unary = append(unary, limiter.UnaryInterceptor, idempotency.UnaryInterceptor, limiter.QuotaInterceptor)
+	unaryChain := grpc_middleware.ChainUnaryServer(unary...)
 
 	streamChain := grpc_middleware.ChainStreamServer(
 		service.StreamAuthInterceptor,
 		service.StreamLoggingInterceptor,
 		grpc_prometheus.StreamServerInterceptor,
+		service.TenantMetricsStreamInterceptor,
+		limiter.StreamInterceptor,
 	)
 
 	// ✅  create server
-	grpcServer := grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(unaryChain),
 		grpc.StreamInterceptor(streamChain),
-	)
+		grpc.MaxRecvMsgSize(int(cfg.Limits.MaxMessageSize)),
+	}
+	if tlsReloader != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsReloader.ServerConfig())))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 
 	// register service and prometheus
-	svc := service.NewDocService(wp)
+	// identical template+data requests are served from cache
+	opts := []service.Option{service.WithAuditStore(auditStore)}
+	if size := int64(cfg.Storage.CacheSize); size > 0 {
+		if cfg.Storage.CacheDir != "" {
+			cache, err := service.NewDiskCache(cfg.Storage.CacheDir, size, cfg.Storage.CacheTTL)
+			if err != nil {
+				log.Fatalf("open cache: %v", err)
+			}
+			opts = append(opts, service.WithCache(cache))
+		} else {
+			opts = append(opts, service.WithCache(service.NewMemoryCache(size, cfg.Storage.CacheTTL)))
+		}
+	}
+	// signing profiles (JSON array of service.SignerConfig), optional
+	if path := cfg.Documents.SignersFile; path != "" {
+		signers, err := service.LoadSigners(path)
+		if err != nil {
+			log.Fatalf("load signers: %v", err)
+		}
+		for name, t := range cfg.Tenants {
+			if _, ok := signers[t.Signer]; t.Signer != "" && !ok {
+				log.Fatalf("tenants.%s.signer: no signer profile %q in %s", name, t.Signer, path)
+			}
+		}
+		opts = append(opts, service.WithSigners(signers))
+	}
+	// hash setiap hasil dicatat untuk VerifyPDF; tanpa file hanya di memori
+	if path := cfg.Storage.RegistryFile; path != "" {
+		registry, err := service.OpenFileRegistry(path)
+		if err != nil {
+			log.Fatalf("open document registry: %v", err)
+		}
+		defer registry.Close()
+		opts = append(opts, service.WithRegistry(registry))
+	} else {
+		opts = append(opts, service.WithRegistry(service.NewMemoryRegistry()))
+	}
+	// CA (PEM) yang dipercaya untuk tanda tangan yang diverifikasi VerifyPDF
+	if path := cfg.Documents.TrustRoots; path != "" {
+		roots, err := service.LoadCertPool(path)
+		if err != nil {
+			log.Fatalf("load trust roots: %v", err)
+		}
+		opts = append(opts, service.WithTrustRoots(roots))
+	}
+	// halaman verifikasi publik untuk QR, mis. https://docs.example.com/verify/{code}
+	if url := cfg.Documents.VerifyURL; url != "" {
+		opts = append(opts, service.WithVerifyURL(url))
+	}
+	// macro/OLE/relasi eksternal di template: "strip" (default) atau "reject"
+	policy, err := service.ParseSanitizePolicy(cfg.Documents.SanitizePolicy)
+	if err != nil {
+		log.Fatalf("sanitize policy: %v", err)
+	}
+	opts = append(opts, service.WithSanitizePolicy(policy))
+	d := cfg.Limits.Docx
+	opts = append(opts, service.WithDocxLimits(service.DocxLimits{
+		MaxCompressedBytes:   int64(d.MaxCompressedSize),
+		MaxUncompressedBytes: int64(d.MaxUncompressedSize),
+		MaxEntries:           d.MaxEntries,
+		MaxCompressionRatio:  d.MaxCompressionRatio,
+		MaxXMLDepth:          d.MaxXMLDepth,
+	}))
+	sb := cfg.Converter.Sandbox
+	service.ConfigureSandbox(service.SandboxConfig{
+		CPUTime:      sb.CPUTime,
+		WallTime:     sb.WallTime,
+		AddressSpace: int64(sb.AddressSpace),
+		OpenFiles:    sb.OpenFiles,
+		FileSize:     int64(sb.FileSize),
+		Namespaces:   sb.Namespaces,
+	})
+	service.SetLibreOfficePath(cfg.Converter.Soffice)
+	svc := service.NewDocService(wp, opts...)
 	docgenpb.RegisterDocServiceServer(grpcServer, svc)
+	var keyAdmin *service.KeyAdmin
+	if keyStore != nil {
+		keyAdmin = service.NewKeyAdmin(keyStore)
+		docgenpb.RegisterKeyAdminServer(grpcServer, keyAdmin)
+	}
 	grpc_prometheus.Register(grpcServer)          // register metrics
 	grpc_prometheus.EnableHandlingTimeHistogram() // optional
 
@@ -62,12 +299,59 @@ func main() {
 	// set status sehat
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 
-	lis, err := net.Listen("tcp", ":5051")
+	// HTTP/JSON gateway for non-gRPC clients, same interceptors as gRPC
+	if addr := cfg.Listen.HTTP; addr != "" {
+		gateway := service.NewHTTPGateway(svc, unaryChain)
+		gateway.SetMaxBodySize(int64(cfg.Limits.MaxMessageSize))
+		if keyAdmin != nil {
+			gateway.RegisterKeyAdmin(keyAdmin)
+		}
+		go func() {
+			log.Printf("HTTP gateway listening %s (tls=%v)", addr, cfg.TLS.Enabled())
+			serve := service.ListenAndServeHTTP
+			if tlsReloader != nil {
+				serve = func(addr string, g *service.HTTPGateway) error {
+					return service.ListenAndServeHTTPS(addr, tlsReloader.ServerConfig(), g)
+				}
+			}
+			if err := serve(addr, gateway); err != nil {
+				log.Fatalf("http gateway failed: %v", err)
+			}
+		}()
+	}
+
+	lis, err := net.Listen("tcp", cfg.Listen.GRPC)
 	if err != nil {
 		log.Fatalf("listen failed: %v", err)
 	}
-	log.Println("gRPC server listening :5051, metrics at :9090/metrics")
+	log.Printf("gRPC server listening %s (tls=%v), metrics at %s/metrics", cfg.Listen.GRPC, cfg.TLS.Enabled(), cfg.Listen.Metrics)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("serve failed: %v", err)
 	}
 }
+
+// tenants converts the tenants section of the config.
+func tenants(cfg map[string]config.Tenant) []service.Tenant {
+	var ts []service.Tenant
+	for name, t := range cfg {
+		st := service.Tenant{Name: name, Signer: t.Signer, FontDirs: t.FontDirs}
+		if wm := t.Watermark; wm != nil {
+			st.Watermark = &docgenpb.Watermark{
+				Text:     wm.Text,
+				FontSize: wm.FontSize,
+				Color:    wm.Color,
+				Opacity:  wm.Opacity,
+				Rotation: wm.Rotation,
+			}
+			if wm.ImageFile != "" {
+				img, err := os.ReadFile(wm.ImageFile)
+				if err != nil {
+					log.Fatalf("tenants.%s.watermark.image_file: %v", name, err)
+				}
+				st.Watermark.Image = img
+			}
+		}
+		ts = append(ts, st)
+	}
+	return ts
+}
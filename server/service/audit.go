@@ -0,0 +1,352 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+	redactedValue     = "[REDACTED]"
+)
+
+// auditedMethods are the calls that produce a document for a caller.
+var auditedMethods = map[string]bool{
+	docgenpb.DocService_GeneratePDF_FullMethodName:    true,
+	docgenpb.DocService_GenerateDocx_FullMethodName:   true,
+	docgenpb.DocService_Generate_FullMethodName:       true,
+	docgenpb.DocService_MergeDocuments_FullMethodName: true,
+}
+
+// AuditStore is an append-only log of audit entries.
+type AuditStore interface {
+	Append(e *docgenpb.AuditEntry) error
+	// Query returns matching entries, newest first.
+	Query(q *docgenpb.AuditQuery) ([]*docgenpb.AuditEntry, error)
+}
+
+// Auditor records every generate and merge call, successful or not, in an
+// AuditStore.
+// By default only hashes of the template, data and output are kept; with
+// IncludeData the data map is stored too, minus the Redact fields.
+type Auditor struct {
+	Store       AuditStore
+	IncludeData bool
+	Redact      []string // data keys, case-insensitive
+}
+
+// UnaryInterceptor must run after the auth interceptor so the caller is known.
+// A successful call whose entry cannot be written fails: a document without
+// an audit trail must not leave the service.
+func (a *Auditor) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !auditedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	var e *docgenpb.AuditEntry
+	switch r := req.(type) {
+	case *docgenpb.GenerateRequest:
+		e = a.entry(ctx, info.FullMethod, r, start)
+	case *docgenpb.MergeRequest:
+		e = a.mergeEntry(ctx, info.FullMethod, r, start)
+	default:
+		return resp, err
+	}
+	if out, ok := resp.(*docgenpb.GenerateResponse); ok && err == nil {
+		sum := sha256.Sum256(out.GetContent())
+		e.OutputSha256 = hex.EncodeToString(sum[:])
+		e.ContentType = out.GetContentType()
+	}
+	st := status.Convert(err)
+	e.Code = st.Code().String()
+	if err != nil {
+		e.Error = st.Message()
+	}
+	if aerr := a.Store.Append(e); aerr != nil {
+		logger.Error("audit append failed", zap.String("method", info.FullMethod), zap.Error(aerr))
+		if err == nil {
+			return nil, status.Error(codes.Internal, "audit trail unavailable")
+		}
+	}
+	return resp, err
+}
+
+func (a *Auditor) entry(ctx context.Context, method string, req *docgenpb.GenerateRequest, start time.Time) *docgenpb.AuditEntry {
+	tpl := sha256.Sum256(req.GetTemplate())
+	e := newAuditEntry(ctx, method, hex.EncodeToString(tpl[:]), dataHash(req.GetData()), start)
+	if sig := req.GetSignature(); sig != nil {
+		e.SignerProfile = signerProfile(ctx, sig)
+	}
+	for k, v := range req.GetData() {
+		a.keep(e, k, k, v)
+	}
+	return e
+}
+
+// mergeEntry records a merge as one entry. Its template hash covers every
+// source (template, PDF or DOCX) in order; the data of generated sources is
+// keyed "<source index>.<field>".
+func (a *Auditor) mergeEntry(ctx context.Context, method string, req *docgenpb.MergeRequest, start time.Time) *docgenpb.AuditEntry {
+	h := sha256.New()
+	data := map[string]string{}
+	fields := map[string]string{} // key in data -> field as sent
+	for i, src := range req.GetSources() {
+		var b []byte
+		switch v := src.GetSource().(type) {
+		case *docgenpb.MergeSource_Generate:
+			b = v.Generate.GetTemplate()
+			for k, val := range v.Generate.GetData() {
+				key := fmt.Sprintf("%d.%s", i, k)
+				data[key], fields[key] = val, k
+			}
+		case *docgenpb.MergeSource_Pdf:
+			b = v.Pdf
+		case *docgenpb.MergeSource_Docx:
+			b = v.Docx
+		}
+		sum := sha256.Sum256(b)
+		h.Write(sum[:])
+	}
+	e := newAuditEntry(ctx, method, hex.EncodeToString(h.Sum(nil)), dataHash(data), start)
+	for key, v := range data {
+		a.keep(e, key, fields[key], v)
+	}
+	return e
+}
+
+func newAuditEntry(ctx context.Context, method, templateSha, dataSha string, start time.Time) *docgenpb.AuditEntry {
+	return &docgenpb.AuditEntry{
+		Id:             newAuditID(),
+		Time:           start.UTC().Format(time.RFC3339Nano),
+		DurationMs:     time.Since(start).Milliseconds(),
+		Method:         method,
+		Caller:         CallerFromContext(ctx),
+		Tenant:         TenantFromContext(ctx),
+		TemplateSha256: templateSha,
+		DataSha256:     dataSha,
+	}
+}
+
+// keep stores a data field in e when IncludeData is set; field is the key as
+// sent by the caller, which Redact is matched against.
+func (a *Auditor) keep(e *docgenpb.AuditEntry, key, field, v string) {
+	if !a.IncludeData {
+		return
+	}
+	if a.redacted(field) {
+		v = redactedValue
+	}
+	if e.Data == nil {
+		e.Data = map[string]string{}
+	}
+	e.Data[key] = v
+}
+
+func (a *Auditor) redacted(key string) bool {
+	for _, r := range a.Redact {
+		if strings.EqualFold(r, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataHash hashes the data map as JSON; encoding/json sorts map keys, so the
+// hash does not depend on field order.
+func dataHash(data map[string]string) string {
+	if data == nil {
+		data = map[string]string{}
+	}
+	b, _ := json.Marshal(data)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func newAuditID() string {
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// WithAuditStore enables QueryAudit over store.
+func WithAuditStore(store AuditStore) Option {
+	return func(s *DocService) { s.audit = store }
+}
+
+func (s *DocService) QueryAudit(ctx context.Context, req *docgenpb.AuditQuery) (*docgenpb.AuditQueryResponse, error) {
+	if s.audit == nil {
+		return nil, status.Error(codes.FailedPrecondition, "audit trail is not configured on this server")
+	}
+	// caller dengan tenant hanya melihat entri tenant-nya sendiri
+	if t := TenantFromContext(ctx); t != "" {
+		if req.GetTenant() != "" && req.GetTenant() != t {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot query audit entries of tenant %q", req.GetTenant())
+		}
+		req = proto.Clone(req).(*docgenpb.AuditQuery)
+		req.Tenant = t
+	}
+	if req.GetOutputSha256() == "" && req.GetCaller() == "" && req.GetTemplateSha256() == "" && req.GetTenant() == "" {
+		return nil, status.Error(codes.InvalidArgument, "one of output_sha256, caller, template_sha256 or tenant is required")
+	}
+	for _, t := range []string{req.GetSince(), req.GetUntil()} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time %q: want RFC 3339", t)
+		}
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > maxAuditLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", maxAuditLimit)
+	}
+	entries, err := s.audit.Query(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query audit: %v", err)
+	}
+	return &docgenpb.AuditQueryResponse{Entries: entries}, nil
+}
+
+// auditMatch reports whether e satisfies every filter set in q.
+func auditMatch(e *docgenpb.AuditEntry, q *docgenpb.AuditQuery) bool {
+	if q.GetOutputSha256() != "" && !strings.EqualFold(e.GetOutputSha256(), q.GetOutputSha256()) {
+		return false
+	}
+	if q.GetCaller() != "" && e.GetCaller() != q.GetCaller() {
+		return false
+	}
+	if q.GetTemplateSha256() != "" && !strings.EqualFold(e.GetTemplateSha256(), q.GetTemplateSha256()) {
+		return false
+	}
+	if q.GetTenant() != "" && e.GetTenant() != q.GetTenant() {
+		return false
+	}
+	if q.GetSince() != "" || q.GetUntil() != "" {
+		t, err := time.Parse(time.RFC3339Nano, e.GetTime())
+		if err != nil {
+			return false
+		}
+		if since, err := time.Parse(time.RFC3339, q.GetSince()); err == nil && t.Before(since) {
+			return false
+		}
+		if until, err := time.Parse(time.RFC3339, q.GetUntil()); err == nil && !t.Before(until) {
+			return false
+		}
+	}
+	return true
+}
+
+func auditLimit(q *docgenpb.AuditQuery) int {
+	if q.GetLimit() > 0 {
+		return int(q.GetLimit())
+	}
+	return defaultAuditLimit
+}
+
+// ---------- JSON lines ----------
+
+var auditJSON = protojson.MarshalOptions{UseProtoNames: true}
+
+// FileAuditStore appends one JSON object per line to a file and answers
+// queries by scanning it.
+type FileAuditStore struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+func OpenFileAuditStore(path string) (*FileAuditStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	// baris terakhir yang terpotong jangan sampai menyambung dengan entri baru
+	if r, err := os.Open(path); err == nil {
+		last := make([]byte, 1)
+		if fi, err := r.Stat(); err == nil && fi.Size() > 0 {
+			if _, err := r.ReadAt(last, fi.Size()-1); err == nil && last[0] != '\n' {
+				_, _ = f.Write([]byte{'\n'})
+			}
+		}
+		r.Close()
+	}
+	return &FileAuditStore{path: path, f: f}, nil
+}
+
+func (s *FileAuditStore) Append(e *docgenpb.AuditEntry) error {
+	b, err := auditJSON.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	// entri audit harus sudah di disk sebelum dokumen dikembalikan
+	return s.f.Sync()
+}
+
+func (s *FileAuditStore) Query(q *docgenpb.AuditQuery) ([]*docgenpb.AuditEntry, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// simpan limit entri terakhir yang cocok, lalu balik urutannya
+	limit := auditLimit(q)
+	var ring []*docgenpb.AuditEntry
+	next := 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64<<10), 16<<20)
+	for line := 1; sc.Scan(); line++ {
+		e := &docgenpb.AuditEntry{}
+		if err := jsonIn.Unmarshal(sc.Bytes(), e); err != nil {
+			// mis. baris terakhir terpotong saat proses mati
+			logger.Warn("skipping unreadable audit entry", zap.String("file", s.path), zap.Int("line", line), zap.Error(err))
+			continue
+		}
+		if !auditMatch(e, q) {
+			continue
+		}
+		if len(ring) < limit {
+			ring = append(ring, e)
+		} else {
+			ring[next] = e
+		}
+		next = (next + 1) % limit
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]*docgenpb.AuditEntry, 0, len(ring))
+	for i := 0; i < len(ring); i++ {
+		out = append(out, ring[(next-1-i+2*len(ring))%len(ring)])
+	}
+	return out, nil
+}
+
+func (s *FileAuditStore) Close() error {
+	return s.f.Close()
+}
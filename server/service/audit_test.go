@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/dedinirtadinata/docxtool/workerpool"
+	"google.golang.org/grpc"
+)
+
+func TestAuditMergeDocuments(t *testing.T) {
+	store, err := OpenFileAuditStore(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	a := &Auditor{Store: store}
+	s := NewDocService(workerpool.NewWorkerPool(1))
+	ctx := withPrincipal(context.Background(), &Principal{ID: "key:a", Tenant: "acme"})
+
+	req := &docgenpb.MergeRequest{Sources: []*docgenpb.MergeSource{
+		{Source: &docgenpb.MergeSource_Pdf{Pdf: samplePDF}},
+		{Source: &docgenpb.MergeSource_Pdf{Pdf: samplePDF}},
+	}}
+	info := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_MergeDocuments_FullMethodName}
+	resp, err := a.UnaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.MergeDocuments(ctx, req.(*docgenpb.MergeRequest))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(resp.(*docgenpb.GenerateResponse).GetContent())
+	entries, err := store.Query(&docgenpb.AuditQuery{OutputSha256: hex.EncodeToString(sum[:])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("%d audit entries for the merged document, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.GetMethod() != info.FullMethod || e.GetCaller() != "key:a" || e.GetTenant() != "acme" || e.GetCode() != "OK" || e.GetContentType() != "application/pdf" {
+		t.Errorf("entry = %v", e)
+	}
+
+	// gagal juga dicatat
+	bad := &docgenpb.MergeRequest{Sources: []*docgenpb.MergeSource{{Source: &docgenpb.MergeSource_Pdf{Pdf: []byte("bukan pdf")}}}}
+	if _, err := a.UnaryInterceptor(ctx, bad, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.MergeDocuments(ctx, req.(*docgenpb.MergeRequest))
+	}); err == nil {
+		t.Fatal("merged a source that is not a PDF")
+	}
+	entries, err = store.Query(&docgenpb.AuditQuery{Caller: "key:a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].GetCode() != "InvalidArgument" || entries[0].GetOutputSha256() != "" {
+		t.Errorf("entries = %v", entries)
+	}
+}
+
+func TestAuditMergeEntryData(t *testing.T) {
+	a := &Auditor{IncludeData: true, Redact: []string{"NIK"}}
+	tpl := []byte("template")
+	req := &docgenpb.MergeRequest{Sources: []*docgenpb.MergeSource{
+		{Source: &docgenpb.MergeSource_Generate{Generate: &docgenpb.GenerateRequest{Template: tpl, Data: map[string]string{"nama": "Budi", "nik": "3171"}}}},
+		{Source: &docgenpb.MergeSource_Pdf{Pdf: samplePDF}},
+		{Source: &docgenpb.MergeSource_Generate{Generate: &docgenpb.GenerateRequest{Template: tpl, Data: map[string]string{"nama": "Sari"}}}},
+	}}
+	e := a.mergeEntry(context.Background(), docgenpb.DocService_MergeDocuments_FullMethodName, req, time.Now())
+	want := map[string]string{"0.nama": "Budi", "0.nik": redactedValue, "2.nama": "Sari"}
+	if len(e.GetData()) != len(want) {
+		t.Fatalf("data = %v, want %v", e.GetData(), want)
+	}
+	for k, v := range want {
+		if e.GetData()[k] != v {
+			t.Errorf("data[%q] = %q, want %q", k, e.GetData()[k], v)
+		}
+	}
+	if e.GetDataSha256() != dataHash(map[string]string{"0.nama": "Budi", "0.nik": "3171", "2.nama": "Sari"}) {
+		t.Error("data hash does not cover the unredacted data of every source")
+	}
+
+	// urutan sumber mengubah hash template
+	req.Sources[0], req.Sources[1] = req.Sources[1], req.Sources[0]
+	if e2 := a.mergeEntry(context.Background(), docgenpb.DocService_MergeDocuments_FullMethodName, req, time.Now()); e2.GetTemplateSha256() == e.GetTemplateSha256() {
+		t.Error("template hash does not depend on source order")
+	}
+}
@@ -2,70 +2,196 @@ package service
 
 import (
 	"context"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"strings"
+	"sync"
+	"time"
 )
 
-// simple in-memory API keys; replace with DB/Redis in production
-var validAPIKeys = map[string]bool{
-	"secret-key-1": true,
-	"secret-key-2": true,
+// Keys from the configuration (auth.api_keys) are kept by hash next to the
+// key store; they have every scope and cannot be rotated or revoked via RPC.
+var (
+	keysMu      sync.RWMutex
+	staticKeys  = map[string]*APIKey{}
+	keyStore    KeyStore
+	jwtVerifier *JWTVerifier
+)
+
+// SetAPIKeys replaces the keys given in plaintext in the configuration.
+// Call it before serving.
+func SetAPIKeys(keys []string) {
+	m := make(map[string]*APIKey, len(keys))
+	for _, k := range keys {
+		h := HashAPIKey(k)
+		// id = awal hash, sama dengan identitas caller sebelum ada key store
+		m[h] = &APIKey{ID: h[len("sha256:"):][:12], Name: "config", Hash: h, Scopes: []string{"*"}}
+	}
+	keysMu.Lock()
+	staticKeys = m
+	keysMu.Unlock()
+}
+
+// SetKeyStore makes the auth interceptors accept the keys in ks as well.
+func SetKeyStore(ks KeyStore) {
+	keysMu.Lock()
+	keyStore = ks
+	keysMu.Unlock()
+}
+
+// SetJWTVerifier makes the auth interceptors accept bearer tokens that look
+// like JWTs (header.payload.signature) and pass v; nil turns JWTs off.
+func SetJWTVerifier(v *JWTVerifier) {
+	keysMu.Lock()
+	jwtVerifier = v
+	keysMu.Unlock()
+}
+
+// Principal is the authenticated caller of a request, from an API key, a
+// JWT or a client certificate.
+type Principal struct {
+	// ID is the stable caller identity used in logs, the audit trail and
+	// idempotency scoping: "key:<key id>", "jwt:<subject>" or
+	// "cert:<certificate name>".
+	ID     string
+	Method string // "api_key", "jwt" or "mtls"
+	Name   string
+	Tenant string
+	Scopes []string
 }
 
-// checkAPIKey returns nil if ok
-func checkAPIKey(md metadata.MD) error {
+// HasScope reports whether the principal was granted scope; "*" grants every
+// scope and "generate:*" every scope starting with "generate:".
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == "*" || strings.HasSuffix(s, ":*") && strings.HasPrefix(scope, s[:len(s)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func apiKeyPrincipal(k *APIKey) *Principal {
+	return &Principal{ID: "key:" + k.ID, Method: "api_key", Name: k.Name, Tenant: k.Tenant, Scopes: k.Scopes}
+}
+
+type principalCtxKey struct{}
+
+// PrincipalFromContext returns the caller set by the auth interceptors; false
+// for unauthenticated calls (e.g. health checks).
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(*Principal)
+	return p, ok
+}
+
+// CallerFromContext returns the authenticated caller identity set by the auth
+// interceptors, or "" for unauthenticated calls (e.g. health checks).
+func CallerFromContext(ctx context.Context) string {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.ID
+	}
+	return ""
+}
+
+// lookupAPIKey finds an active key by its secret.
+func lookupAPIKey(secret string) (*Principal, error) {
+	h := HashAPIKey(secret)
+	keysMu.RLock()
+	k, ok := staticKeys[h]
+	ks := keyStore
+	keysMu.RUnlock()
+	if !ok && ks != nil {
+		k, ok = ks.Lookup(h)
+	}
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid api key")
+	}
+	if why := k.usable(time.Now()); why != "" {
+		return nil, status.Error(codes.PermissionDenied, why)
+	}
+	return apiKeyPrincipal(k), nil
+}
+
+// bearer checks an "Authorization: Bearer" value: a JWT when a verifier is
+// configured and the value has the JWT shape, an API key otherwise.
+func bearer(v string) (*Principal, error) {
+	keysMu.RLock()
+	jv := jwtVerifier
+	keysMu.RUnlock()
+	if jv != nil && strings.Count(v, ".") == 2 {
+		return jv.Verify(v)
+	}
+	return lookupAPIKey(v)
+}
+
+// authenticate returns the caller of a request: an API key or bearer token
+// in its metadata, else a verified client certificate of the connection.
+func authenticate(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
 	// metadata key lower-case normalized by gRPC
 	if vals := md.Get("x-api-key"); len(vals) > 0 {
-		if validAPIKeys[vals[0]] {
-			return nil
-		}
-		return status.Error(codes.PermissionDenied, "invalid api key")
+		return lookupAPIKey(vals[0])
 	}
 	if vals := md.Get("authorization"); len(vals) > 0 {
-		// support "Bearer <key>" or raw key
+		// support "Bearer <key|jwt>" or raw key
 		v := vals[0]
 		if len(v) > 7 && (v[:7] == "Bearer " || v[:7] == "bearer ") {
-			key := v[7:]
-			if validAPIKeys[key] {
-				return nil
-			}
-			return status.Error(codes.PermissionDenied, "invalid bearer token")
+			return bearer(v[7:])
 		}
-		if validAPIKeys[v] {
-			return nil
+		return lookupAPIKey(v)
+	}
+	if p, ok, err := certPrincipal(ctx); ok {
+		if err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
-		return status.Error(codes.PermissionDenied, "invalid authorization")
+		return p, nil
 	}
-	return status.Error(codes.Unauthenticated, "missing api key")
+	return nil, status.Error(codes.Unauthenticated, "missing api key or bearer token")
 }
 
-func UnaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	md, _ := metadata.FromIncomingContext(ctx)
+func withPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
 
+func UnaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	// 🚨 bypass untuk health check
 	if strings.HasPrefix(info.FullMethod, "/grpc.health.v1.Health/") {
 		return handler(ctx, req)
 	}
 
-	if err := checkAPIKey(md); err != nil {
+	p, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorize(p, info.FullMethod); err != nil {
+		return nil, err
+	}
+	if err := checkTenant(p); err != nil {
 		return nil, err
 	}
-	return handler(ctx, req)
+	return handler(withPrincipal(ctx, p), req)
 }
 
 func StreamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	md, _ := metadata.FromIncomingContext(ss.Context())
-
 	// 🚨 bypass untuk health check
 	if strings.HasPrefix(info.FullMethod, "/grpc.health.v1.Health/") {
 		return handler(srv, ss)
 	}
 
-	if err := checkAPIKey(md); err != nil {
+	p, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err := authorize(p, info.FullMethod); err != nil {
+		return err
+	}
+	if err := checkTenant(p); err != nil {
 		return err
 	}
-	return handler(srv, ss)
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = withPrincipal(ss.Context(), p)
+	return handler(srv, wrapped)
 }
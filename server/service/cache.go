@@ -0,0 +1,292 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// rendererVersion is part of every cache key; bump it whenever the rendering
+// pipeline changes output for the same input so stale results are not served.
+const rendererVersion = "docxtool-render-1"
+
+// ResultCache stores rendered documents by content-addressed key.
+type ResultCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// cacheKey hashes everything that influences the output of a generate call.
+// Per-call fields that do not change the document (bypass_cache,
+// idempotency_key) are cleared first; the format is carried by kind so
+// GeneratePDF and Generate(PDF) share entries. The verification stamp and the
+// signature are unique per call and applied after the cache, so those requests
+// share the rendered document with plain ones. Tenants never share entries.
+func cacheKey(tenant, kind string, req *docgenpb.GenerateRequest) (string, error) {
+	r := proto.Clone(req).(*docgenpb.GenerateRequest)
+	r.BypassCache = false
+	r.IdempotencyKey = ""
+	r.OutputFormat = docgenpb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+	r.Signature = nil
+	r.Verification = nil
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(rendererVersion + "\x00" + tenant + "\x00" + kind + "\x00"))
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cached serves a generate call from s.cache when possible and stores fresh results.
+func (s *DocService) cached(ctx context.Context, kind string, req *docgenpb.GenerateRequest, gen func() (*docgenpb.GenerateResponse, error)) (*docgenpb.GenerateResponse, error) {
+	if s.cache == nil {
+		return gen()
+	}
+	tenant := TenantFromContext(ctx)
+	if req.GetBypassCache() {
+		cacheRequests.WithLabelValues(tenant, "bypass").Inc()
+		return gen()
+	}
+	key, err := cacheKey(tenant, kind, req)
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := s.cache.Get(key); ok {
+		resp := &docgenpb.GenerateResponse{}
+		if err := proto.Unmarshal(b, resp); err == nil {
+			cacheRequests.WithLabelValues(tenant, "hit").Inc()
+			return resp, nil
+		}
+	}
+	cacheRequests.WithLabelValues(tenant, "miss").Inc()
+
+	resp, err := gen()
+	if err != nil {
+		return nil, err
+	}
+	if b, err := proto.Marshal(resp); err == nil {
+		s.cache.Set(key, b)
+	}
+	return resp, nil
+}
+
+// ---------- in-memory LRU ----------
+
+type memEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an LRU cache bounded by total value size, with per-entry TTL.
+type MemoryCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	ttl      time.Duration
+	size     int64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewMemoryCache creates an LRU cache holding at most maxBytes of values.
+// ttl <= 0 means entries never expire.
+func NewMemoryCache(maxBytes int64, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*memEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte) {
+	if int64(len(value)) > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	e := &memEntry{key: key, value: value}
+	if c.ttl > 0 {
+		e.expires = time.Now().Add(c.ttl)
+	}
+	c.items[key] = c.ll.PushFront(e)
+	c.size += int64(len(value))
+
+	for c.size > c.maxBytes {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	e := el.Value.(*memEntry)
+	c.ll.Remove(el)
+	delete(c.items, e.key)
+	c.size -= int64(len(e.value))
+}
+
+// ---------- on-disk ----------
+
+// DiskCache keeps one file per key under dir. Entries written more than ttl
+// ago (by mtime, never touched on hit) are treated as missing; when the total
+// size exceeds maxBytes the least recently used files are deleted. Use is
+// tracked in memory, so after a restart files are ordered by write time.
+type DiskCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	ttl      time.Duration
+	size     int64
+	used     map[string]time.Time // last hit or write, by path
+}
+
+func NewDiskCache(dir string, maxBytes int64, ttl time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{dir: dir, maxBytes: maxBytes, ttl: ttl, used: map[string]time.Time{}}
+	for _, f := range c.files() {
+		c.size += f.size
+	}
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+	return c, nil
+}
+
+func (c *DiskCache) path(key string) string { return filepath.Join(c.dir, key) }
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.path(key)
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(fi.ModTime()) > c.ttl {
+		c.removeFile(p, fi.Size())
+		return nil, false
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	c.used[p] = time.Now()
+	return b, true
+}
+
+func (c *DiskCache) Set(key string, value []byte) {
+	if int64(len(value)) > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.path(key)
+	if fi, err := os.Stat(p); err == nil {
+		c.size -= fi.Size()
+	}
+	// write then rename so readers never see a partial file
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		logger.Warn("disk cache write failed", zap.Error(err))
+		return
+	}
+	_, werr := tmp.Write(value)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	c.size += int64(len(value))
+	c.used[p] = time.Now()
+	c.evict()
+}
+
+type diskFile struct {
+	path string
+	size int64
+	used time.Time // last hit or write; mtime when not known
+}
+
+func (c *DiskCache) files() []diskFile {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+	out := make([]diskFile, 0, len(entries))
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		out = append(out, diskFile{path: filepath.Join(c.dir, e.Name()), size: fi.Size(), used: fi.ModTime()})
+	}
+	return out
+}
+
+// evict must be called with c.mu held.
+func (c *DiskCache) evict() {
+	if c.size <= c.maxBytes {
+		return
+	}
+	files := c.files()
+	for i, f := range files {
+		if t, ok := c.used[f.path]; ok {
+			files[i].used = t
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].used.Before(files[j].used) })
+	for _, f := range files {
+		if c.size <= c.maxBytes {
+			return
+		}
+		c.removeFile(f.path, f.size)
+	}
+}
+
+func (c *DiskCache) removeFile(p string, size int64) {
+	if err := os.Remove(p); err == nil {
+		c.size -= size
+	}
+	delete(c.used, p)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheTTLIgnoresHits(t *testing.T) {
+	ttl := 400 * time.Millisecond
+	c, err := NewDiskCache(t.TempDir(), 1<<20, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("k", []byte("hasil"))
+	start := time.Now()
+	// hit terus-menerus tidak boleh memperpanjang umur entri
+	for time.Since(start) < ttl*3/4 {
+		if b, ok := c.Get("k"); !ok || !bytes.Equal(b, []byte("hasil")) {
+			t.Fatal("entry missing before its ttl")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(time.Until(start.Add(ttl * 5 / 4)))
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("entry read during its ttl outlived it")
+	}
+	if c.size != 0 {
+		t.Errorf("size after expiry = %d", c.size)
+	}
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := bytes.Repeat([]byte("x"), 10)
+	c.Set("a", v)
+	c.Set("b", v)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a missing")
+	}
+	c.Set("c", v)
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("%s cached = %v, want %v", key, ok, want)
+		}
+	}
+
+	// setelah restart urutan dari waktu tulis, dan ukuran dihitung ulang
+	c2, err := NewDiskCache(dir, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2.size != 20 {
+		t.Errorf("size after reopen = %d, want 20", c2.size)
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/digitorus/timestamp"
+)
+
+var (
+	oidData                     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttrContentType          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigest        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttrSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+	oidAttrTimestampToken       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 14}
+	oidSHA256                   = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSAEncryption            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256          = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+
+	// id-TSPolicy for the local development TSA; not a real policy.
+	oidLocalTSAPolicy = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+)
+
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	EncapContentInfo cmsEncapContentInfo
+	Certificates     asn1.RawValue `asn1:"optional"`
+	SignerInfos      asn1.RawValue
+}
+
+type cmsEncapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+}
+
+type cmsSignerInfo struct {
+	Version            int
+	SID                cmsIssuerAndSerial
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional"`
+}
+
+type cmsIssuerAndSerial struct {
+	Issuer asn1.RawValue
+	Serial *big.Int
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// ESS signing-certificate-v2 (RFC 5035), required by PAdES baseline.
+type essSigningCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+type essCertIDv2 struct {
+	CertHash     []byte // SHA-256, the default hashAlgorithm, so it is omitted
+	IssuerSerial essIssuerSerial
+}
+
+type essIssuerSerial struct {
+	Issuer []asn1.RawValue // GeneralNames with one directoryName
+	Serial *big.Int
+}
+
+// signCMS returns a detached CMS SignedData over content for a PAdES
+// (ETSI.CAdES.detached) signature: content-type, message-digest and
+// signing-certificate-v2 are signed; no signing-time (the PDF /M entry
+// carries the claimed time). With tsa set the signature value is timestamped
+// and the token added as an unsigned attribute.
+func signCMS(ctx context.Context, content []byte, cert *x509.Certificate, chain []*x509.Certificate, key crypto.Signer, tsa Timestamper) ([]byte, error) {
+	var sigAlg pkix.AlgorithmIdentifier
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		sigAlg = pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}
+	case *ecdsa.PublicKey:
+		sigAlg = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key.Public())
+	}
+
+	digest := sha256.Sum256(content)
+	certHash := sha256.Sum256(cert.Raw)
+	essCert, err := asn1.Marshal(essSigningCertificateV2{Certs: []essCertIDv2{{
+		CertHash: certHash[:],
+		IssuerSerial: essIssuerSerial{
+			Issuer: []asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: cert.RawIssuer}},
+			Serial: cert.SerialNumber,
+		},
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	contentType, _ := asn1.Marshal(oidData)
+	messageDigest, _ := asn1.Marshal(digest[:])
+	signedAttrs, err := marshalAttributes(
+		cmsAttributeValue{oidAttrContentType, contentType},
+		cmsAttributeValue{oidAttrMessageDigest, messageDigest},
+		cmsAttributeValue{oidAttrSigningCertificateV2, essCert},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// signature dihitung atas SET OF attribute (tag universal), bukan [0]
+	toSign, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signedAttrs})
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(toSign)
+	signature, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	si := cmsSignerInfo{
+		Version:            1,
+		SID:                cmsIssuerAndSerial{Issuer: asn1.RawValue{FullBytes: cert.RawIssuer}, Serial: cert.SerialNumber},
+		DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+		SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedAttrs},
+		SignatureAlgorithm: sigAlg,
+		Signature:          signature,
+	}
+	if tsa != nil {
+		sigDigest := sha256.Sum256(signature)
+		token, err := tsa.Timestamp(ctx, sigDigest[:])
+		if err != nil {
+			return nil, fmt.Errorf("timestamp: %w", err)
+		}
+		unsigned, err := marshalAttributes(cmsAttributeValue{oidAttrTimestampToken, token})
+		if err != nil {
+			return nil, err
+		}
+		si.UnsignedAttrs = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 1, IsCompound: true, Bytes: unsigned}
+	}
+	siDER, err := asn1.Marshal(si)
+	if err != nil {
+		return nil, err
+	}
+	digestAlg, err := asn1.Marshal(pkix.AlgorithmIdentifier{Algorithm: oidSHA256})
+	if err != nil {
+		return nil, err
+	}
+
+	var certs bytes.Buffer
+	certs.Write(cert.Raw)
+	for _, c := range chain {
+		certs.Write(c.Raw)
+	}
+	sd, err := asn1.Marshal(cmsSignedData{
+		Version:          1,
+		DigestAlgorithms: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: digestAlg},
+		EncapContentInfo: cmsEncapContentInfo{EContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certs.Bytes()},
+		SignerInfos:      asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: siDER},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(cmsContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+}
+
+type cmsAttributeValue struct {
+	oid   asn1.ObjectIdentifier
+	value []byte // DER of the single attribute value
+}
+
+// marshalAttributes returns the DER contents of a SET OF Attribute, sorted
+// as DER requires.
+func marshalAttributes(attrs ...cmsAttributeValue) ([]byte, error) {
+	encoded := make([][]byte, 0, len(attrs))
+	for _, a := range attrs {
+		b, err := asn1.Marshal(cmsAttribute{
+			Type:   a.oid,
+			Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: a.value},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, b)
+	}
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return bytes.Join(encoded, nil), nil
+}
+
+// ---------- RFC 3161 ----------
+
+// Timestamper obtains an RFC 3161 TimeStampToken (DER ContentInfo) for a
+// SHA-256 digest.
+type Timestamper interface {
+	Timestamp(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// HTTPTimestamper asks a TSA over HTTP (RFC 3161 section 3.4).
+type HTTPTimestamper struct {
+	URL    string
+	Client *http.Client
+}
+
+func (t *HTTPTimestamper) Timestamp(ctx context.Context, digest []byte) ([]byte, error) {
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	tsReq := &timestamp.Request{HashAlgorithm: crypto.SHA256, HashedMessage: digest, Certificates: true, Nonce: nonce}
+	body, err := tsReq.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/timestamp-query")
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tsa %s: %s", t.URL, resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	ts, err := timestamp.ParseResponse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("tsa %s: %w", t.URL, err)
+	}
+	if !bytes.Equal(ts.HashedMessage, digest) || ts.Nonce == nil || ts.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("tsa %s: response does not match request", t.URL)
+	}
+	return ts.RawToken, nil
+}
+
+// LocalTimestamper issues timestamps in-process with its own certificate.
+// It stands in for a real TSA in development and tests; its tokens are not
+// trusted by PDF readers.
+type LocalTimestamper struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+func (t *LocalTimestamper) Timestamp(_ context.Context, digest []byte) ([]byte, error) {
+	ts := &timestamp.Timestamp{
+		HashAlgorithm:     crypto.SHA256,
+		HashedMessage:     digest,
+		Time:              time.Now(),
+		Policy:            oidLocalTSAPolicy,
+		AddTSACertificate: true,
+	}
+	raw, err := ts.CreateResponseWithOpts(t.Cert, t.Key, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := timestamp.ParseResponse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.RawToken, nil
+}
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/xml"
 	"fmt"
 	"github.com/dedinirtadinata/docxtool/workerpool"
@@ -18,14 +19,41 @@ import (
 
 	"baliance.com/gooxml/document"
 	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type DocService struct {
 	docgenpb.UnimplementedDocServiceServer
-	wp *workerpool.WorkerPool
+	wp       *workerpool.WorkerPool
+	cache    ResultCache
+	signers  map[string]*Signer
+	roots    *x509.CertPool
+	registry DocumentRegistry
+	audit    AuditStore
+	limits   DocxLimits
+	sanitize SanitizePolicy
+	// public verification page for stamped documents, see WithVerifyURL
+	verifyURL string
 }
 
-func NewDocService(wp *workerpool.WorkerPool) *DocService { return &DocService{wp: wp} }
+// Option configures optional DocService features.
+type Option func(*DocService)
+
+// WithCache serves repeated generate requests (same template, data and
+// options) from c instead of rendering them again.
+func WithCache(c ResultCache) Option {
+	return func(s *DocService) { s.cache = c }
+}
+
+func NewDocService(wp *workerpool.WorkerPool, opts ...Option) *DocService {
+	s := &DocService{wp: wp, limits: DefaultDocxLimits}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
 
 // ---------- Utilities ----------
 
@@ -146,7 +174,17 @@ func extractPlaceholders(docxPath string) ([]string, error) {
 	return placeholders, nil
 }
 
+// sofficePath overrides the search in detectLibreOffice, see SetLibreOfficePath.
+var sofficePath string
+
+// SetLibreOfficePath makes conversions use path instead of searching for
+// soffice. Call it before serving.
+func SetLibreOfficePath(path string) { sofficePath = path }
+
 func detectLibreOffice() (string, error) {
+	if sofficePath != "" {
+		return sofficePath, nil
+	}
 	candidates := []string{"soffice", "libreoffice"}
 
 	if runtime.GOOS == "darwin" {
@@ -193,30 +231,34 @@ func writeTemp(prefix, suffix string, data []byte) (string, error) {
 	return f.Name(), nil
 }
 
-func convertDocxToPDF(docxPath string) ([]byte, error) {
+func convertDocxToPDF(docxPath string, fontDirs []string) ([]byte, error) {
+	return convertDocx(docxPath, "pdf", "pdf", fontDirs)
+}
+
+// convertDocx runs LibreOffice with --convert-to target (e.g. "odt:writer8")
+// and returns the produced file, which LibreOffice names <base>.<ext>.
+// fontDirs are searched for fonts in addition to the system fonts.
+func convertDocx(docxPath, target, ext string, fontDirs []string) ([]byte, error) {
 	soffice, err := detectLibreOffice()
 	if err != nil {
 		return nil, err
 	}
-	outDir, err := os.MkdirTemp("", "pdf-out-*")
+	// setiap konversi di sandbox sendiri: profil, HOME, env dan rlimit terpisah
+	job, err := newSandboxJob(docxPath, fontDirs)
 	if err != nil {
 		return nil, err
 	}
-	defer os.RemoveAll(outDir)
-
-	cmd := exec.Command(soffice, "--headless", "--convert-to", "pdf", "--outdir", outDir, docxPath)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("libreoffice convert: %v, stderr: %s", err, stderr.String())
+	defer job.remove()
+	if err := job.run(soffice, "--headless", "--convert-to", target, "--outdir", "out", job.input); err != nil {
+		return nil, err
 	}
 
-	pdfPath := filepath.Join(outDir, strings.TrimSuffix(filepath.Base(docxPath), filepath.Ext(docxPath))+".pdf")
-	pdfBytes, err := os.ReadFile(pdfPath)
+	outPath := filepath.Join(job.dir, "out", strings.TrimSuffix(filepath.Base(docxPath), filepath.Ext(docxPath))+"."+ext)
+	outBytes, err := os.ReadFile(outPath)
 	if err != nil {
 		return nil, err
 	}
-	return pdfBytes, nil
+	return outBytes, nil
 }
 
 // ---------- RPCs ----------
@@ -225,6 +267,9 @@ func (s *DocService) GetPlaceholders(ctx context.Context, req *docgenpb.Template
 	if len(req.GetTemplate()) == 0 {
 		return nil, fmt.Errorf("template is empty")
 	}
+	if err := s.checkDocx("template", req.GetTemplate()); err != nil {
+		return nil, err
+	}
 	tmp, err := writeTemp("tpl", ".docx", req.Template)
 	if err != nil {
 		return nil, err
@@ -245,59 +290,134 @@ func (s *DocService) GetPlaceholders(ctx context.Context, req *docgenpb.Template
 }
 
 func (s *DocService) GenerateDocx(ctx context.Context, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+	return s.generate(ctx, req, docgenpb.OutputFormat_OUTPUT_FORMAT_DOCX)
+}
 
+func (s *DocService) GeneratePDF(ctx context.Context, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+	return s.generate(ctx, req, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF)
+}
+
+func (s *DocService) Generate(ctx context.Context, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+	if req.GetOutputFormat() == docgenpb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "output_format is required")
+	}
+	return s.generate(ctx, req, req.GetOutputFormat())
+}
+
+func (s *DocService) generate(ctx context.Context, req *docgenpb.GenerateRequest, format docgenpb.OutputFormat) (*docgenpb.GenerateResponse, error) {
+	out, ok := outputFormats[format]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "output format %v is not supported", format)
+	}
+	req = withTenantDefaults(ctx, req, format)
 	if len(req.GetTemplate()) == 0 {
 		return nil, fmt.Errorf("template is empty")
 	}
-	tmp, err := writeTemp("tpl", ".docx", req.Template)
+	if err := s.checkDocx("template", req.GetTemplate()); err != nil {
+		return nil, err
+	}
+	tpl, sanitized, err := s.sanitizeInput("template", req.GetTemplate())
 	if err != nil {
 		return nil, err
 	}
-	defer os.Remove(tmp)
-	// Apply placeholders
-	job := func() (*docgenpb.GenerateResponse, error) {
-		tpl, err := docx.Open(tmp)
+	if req.GetPdf() != nil {
+		if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF {
+			return nil, status.Errorf(codes.Unimplemented, "pdf options are not supported for %v", format)
+		}
+		filter, err := pdfExportFilter(req.GetPdf())
 		if err != nil {
 			return nil, err
 		}
-
-		var mappingData = docx.PlaceholderMap{}
-		for k, v := range req.Data {
-			mappingData[k] = v
+		out.filter = filter
+	}
+	if wm := req.GetWatermark(); wm != nil {
+		if format == docgenpb.OutputFormat_OUTPUT_FORMAT_TXT {
+			return nil, status.Error(codes.Unimplemented, "watermark is not supported for text output")
+		}
+		if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF && wm.GetPages() != "" {
+			return nil, status.Errorf(codes.Unimplemented, "watermark pages are not supported for %v", format)
+		}
+		if req.GetPdf().GetPdfa() != docgenpb.PdfALevel_PDFA_NONE {
+			// teks watermark (Helvetica, transparan) tidak di-embed
+			return nil, status.Error(codes.Unimplemented, "watermark is not supported with PDF/A")
+		}
+	}
+	if req.GetSecurity() != nil {
+		if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF {
+			return nil, status.Errorf(codes.Unimplemented, "pdf security is not supported for %v", format)
+		}
+		if req.GetPdf().GetPdfa() != docgenpb.PdfALevel_PDFA_NONE {
+			return nil, status.Error(codes.InvalidArgument, "PDF/A does not allow encryption")
+		}
+	}
+	var signer *Signer
+	if sig := req.GetSignature(); sig != nil {
+		if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF {
+			return nil, status.Errorf(codes.Unimplemented, "pdf signature is not supported for %v", format)
+		}
+		if req.GetSecurity() != nil {
+			return nil, status.Error(codes.Unimplemented, "signing encrypted PDFs is not supported")
+		}
+		if sig.GetAppearance() != nil && req.GetPdf().GetPdfa() != docgenpb.PdfALevel_PDFA_NONE {
+			// font tampilan (Helvetica) tidak di-embed
+			return nil, status.Error(codes.Unimplemented, "visible signature appearance is not supported with PDF/A")
 		}
-		if err := tpl.ReplaceAll(mappingData); err != nil {
+		var err error
+		if signer, err = s.signer(ctx, sig); err != nil {
 			return nil, err
 		}
+	}
+	if err := s.checkVerification(req, format); err != nil {
+		return nil, err
+	}
+	renderReq := req
+	if len(sanitized) > 0 || (req.GetVerification() != nil && req.GetSecurity() != nil) {
+		renderReq = proto.Clone(req).(*docgenpb.GenerateRequest)
+		renderReq.Template = tpl
+		if req.GetVerification() != nil {
+			// stamp harus sebelum enkripsi, jadi enkripsi dipindah ke setelah stamp
+			renderReq.Security = nil
+		}
+	}
+	resp, err := s.cached(ctx, format.String(), renderReq, func() (*docgenpb.GenerateResponse, error) {
+		return s.render(ctx, renderReq, out)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// Write to buffer
-		var buf bytes.Buffer
-		if err := tpl.Write(&buf); err != nil {
+	// kode verifikasi & tanda tangan unik per panggilan, jadi dipasang setelah cache
+	if v := req.GetVerification(); v != nil {
+		code := newDocumentCode()
+		content, err := stampVerification(resp.GetContent(), v, code, s.documentURL(code))
+		if err != nil {
 			return nil, err
 		}
-
-		filename := req.GetFilenameHint()
-		if filename == "" {
-			filename = "result.docx"
-		} else if !strings.HasSuffix(strings.ToLower(filename), ".docx") {
-			filename += ".docx"
+		if sec := req.GetSecurity(); sec != nil {
+			if content, err = encryptPDF(content, sec); err != nil {
+				return nil, err
+			}
 		}
-
-		return &docgenpb.GenerateResponse{
-			Content:     buf.Bytes(),
-			ContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-			Filename:    filename,
-		}, nil
+		resp = &docgenpb.GenerateResponse{Content: content, ContentType: resp.GetContentType(), Filename: resp.GetFilename(), DocumentCode: code}
 	}
-
-	// Submit job ke worker pool
-	return s.wp.SubmitJob(ctx, job)
+	signerName := ""
+	if signer != nil {
+		signed, err := signer.SignPDF(ctx, resp.GetContent(), req.GetSignature())
+		if err != nil {
+			return nil, err
+		}
+		resp = &docgenpb.GenerateResponse{Content: signed, ContentType: resp.GetContentType(), Filename: resp.GetFilename(), DocumentCode: resp.GetDocumentCode()}
+		signerName = signer.name
+	}
+	if err := s.record(ctx, resp, signerName); err != nil {
+		return nil, err
+	}
+	resp.Sanitized = sanitized
+	return resp, nil
 }
 
-func (s *DocService) GeneratePDF(ctx context.Context, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+func (s *DocService) render(ctx context.Context, req *docgenpb.GenerateRequest, out outputFormat) (*docgenpb.GenerateResponse, error) {
 	// 1) siapkan docx sementara dari template + replace
-	if len(req.GetTemplate()) == 0 {
-		return nil, fmt.Errorf("template is empty")
-	}
 	tmp, err := writeTemp("tpl", ".docx", req.Template)
 	if err != nil {
 		return nil, err
@@ -305,51 +425,77 @@ func (s *DocService) GeneratePDF(ctx context.Context, req *docgenpb.GenerateRequ
 	defer os.Remove(tmp)
 
 	job := func() (*docgenpb.GenerateResponse, error) {
-
-		doc, err := docx.Open(tmp)
+		filled, err := fillTemplate(tmp, req.Data)
 		if err != nil {
 			return nil, err
 		}
-
-		var mappingData = docx.PlaceholderMap{}
-
-		for k, v := range req.Data {
-			mappingData[k] = v
-		}
-
-		if err := doc.ReplaceAll(mappingData); err != nil {
-			return nil, err
+		// PDF di-stamp setelah konversi, format lain lewat header DOCX
+		if wm := req.GetWatermark(); wm != nil && out.ext != "pdf" {
+			if filled, err = watermarkDocx(filled, wm); err != nil {
+				return nil, err
+			}
 		}
 
-		outDocx := tmp + ".filled.docx"
-
-		err = doc.WriteToFile(outDocx)
-		defer os.Remove(outDocx)
-
-		if err != nil {
-			return nil, err
+		// 2) DOCX & TXT langsung di Go, sisanya convert via LibreOffice
+		var content []byte
+		switch {
+		case out.filter == "" && out.ext == "docx":
+			content = filled
+		case out.filter == "" && out.ext == "txt":
+			content, err = docxToText(filled)
+		default:
+			outDocx := tmp + ".filled.docx"
+			if err := os.WriteFile(outDocx, filled, 0o600); err != nil {
+				return nil, err
+			}
+			defer os.Remove(outDocx)
+			if out.raster {
+				var pdf []byte
+				if pdf, err = convertDocxToPDF(outDocx, tenantFontDirs(ctx)); err == nil {
+					content, err = pageImage(ctx, pdf, out)
+				}
+				break
+			}
+			content, err = convertDocx(outDocx, out.filter, out.ext, tenantFontDirs(ctx))
 		}
-
-		// 2) convert ke PDF via LibreOffice
-		pdfBytes, err := convertDocxToPDF(outDocx)
 		if err != nil {
 			return nil, err
 		}
-
-		filename := req.GetFilenameHint()
-		if filename == "" {
-			filename = "result.pdf"
-		} else if !strings.HasSuffix(strings.ToLower(filename), ".pdf") {
-			filename += ".pdf"
+		if out.ext == "pdf" {
+			if content, err = postProcessPDF(content, req); err != nil {
+				return nil, err
+			}
 		}
 
 		return &docgenpb.GenerateResponse{
-			Content:     pdfBytes,
-			ContentType: "application/pdf",
-			Filename:    filename,
+			Content:     content,
+			ContentType: out.contentType,
+			Filename:    outputFilename(req.GetFilenameHint(), out.ext),
 		}, nil
-
 	}
 	// Submit job ke worker pool
 	return s.wp.SubmitJob(ctx, job)
 }
+
+// fillTemplate replaces placeholders in the DOCX at tplPath and returns the result.
+func fillTemplate(tplPath string, data map[string]string) ([]byte, error) {
+	doc, err := docx.Open(tplPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var mappingData = docx.PlaceholderMap{}
+	for k, v := range data {
+		mappingData[k] = v
+	}
+	if err := doc.ReplaceAll(mappingData); err != nil {
+		return nil, err
+	}
+
+	// Write to buffer
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testDocx returns a minimal DOCX whose body is a single paragraph.
+func testDocx(t *testing.T, text string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		contentTypesPart: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`,
+		"_rels/.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`,
+		mainDocumentPart: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body><w:p><w:r><w:t>` + xmlEscape(text) + `</w:t></w:r></w:p></w:body>
+</w:document>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{contentTypesPart, "_rels/.rels", mainDocumentPart} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(parts[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateRejectsPDFAConflicts(t *testing.T) {
+	s := NewDocService(nil)
+	pdfa := &docgenpb.PdfOptions{Pdfa: docgenpb.PdfALevel_PDFA_2B}
+	tests := []struct {
+		name string
+		req  *docgenpb.GenerateRequest
+		want codes.Code
+	}{
+		{"watermark", &docgenpb.GenerateRequest{Pdf: pdfa, Watermark: &docgenpb.Watermark{Text: "DRAFT"}}, codes.Unimplemented},
+		{"security", &docgenpb.GenerateRequest{Pdf: pdfa, Security: &docgenpb.PdfSecurity{UserPassword: "rahasia"}}, codes.InvalidArgument},
+		{"signature appearance", &docgenpb.GenerateRequest{Pdf: pdfa, Signature: &docgenpb.PdfSignature{Appearance: &docgenpb.SignatureAppearance{Page: 1}}}, codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Template = testDocx(t, "Surat {{nama}}")
+			_, err := s.GeneratePDF(context.Background(), tt.req)
+			if status.Code(err) != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,795 @@
+package service
+
+import (
+	"fmt"
+	"hash/crc32"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	reStyleRef      = regexp.MustCompile(`(<w:(?:pStyle|rStyle|tblStyle|basedOn|next|link|styleLink|numStyleLink)\b[^>]*?\bw:val=")([^"]*)(")`)
+	reNumIDRef      = regexp.MustCompile(`(<w:numId\b[^>]*?\bw:val=")(\d+)(")`)
+	reFootnoteRef   = regexp.MustCompile(`(<w:footnoteReference\b[^>]*?\bw:id=")(-?\d+)(")`)
+	reEndnoteRef    = regexp.MustCompile(`(<w:endnoteReference\b[^>]*?\bw:id=")(-?\d+)(")`)
+	reRelIDAttr     = regexp.MustCompile(`(\br:(?:id|embed|link|pict|dm|lo|qs|cs)=")([^"]*)(")`)
+	reBookmarkID    = regexp.MustCompile(`(<w:bookmark(?:Start|End)\b[^>]*?\bw:id=")(\d+)(")`)
+	reDocPrID       = regexp.MustCompile(`(<wp:docPr\b[^>]*?\bid=")(\d+)(")`)
+	reStyleDef      = regexp.MustCompile(`(?s)<w:style\b[^>]*>.*?</w:style>`)
+	reStyleIDAttr   = regexp.MustCompile(`(\bw:styleId=")([^"]*)(")`)
+	reStyleName     = regexp.MustCompile(`(<w:name\b[^>]*?\bw:val=")([^"]*)(")`)
+	reAttrType      = regexp.MustCompile(`\bw:type="([^"]*)"`)
+	reAttrDefault   = regexp.MustCompile(`\bw:default="(?:1|true|on)"`)
+	reAbstractNum   = regexp.MustCompile(`(?s)<w:abstractNum\b[^>]*>.*?</w:abstractNum>`)
+	reNumDef        = regexp.MustCompile(`(?s)<w:num\b[^>]*>.*?</w:num>`)
+	reAbstractIDDef = regexp.MustCompile(`(\bw:abstractNumId=")(\d+)(")`)
+	reAbstractIDRef = regexp.MustCompile(`(<w:abstractNumId\b[^>]*?\bw:val=")(\d+)(")`)
+	reNumIDDef      = regexp.MustCompile(`(\bw:numId=")(\d+)(")`)
+	reNsid          = regexp.MustCompile(`(<w:nsid\b[^>]*?\bw:val=")([0-9A-Fa-f]*)(")`)
+	rePicBullet     = regexp.MustCompile(`<w:lvlPicBulletId\b[^>]*/>`)
+	reNoteDef       = regexp.MustCompile(`(?s)<w:(?:footnote|endnote)\b[^>]*>.*?</w:(?:footnote|endnote)>`)
+	reNoteID        = regexp.MustCompile(`(\bw:id=")(-?\d+)(")`)
+	reTitlePg       = regexp.MustCompile(`<w:titlePg(?:\s*/>|\s[^>]*\bw:val="(?:1|true|on)"[^>]*/>)`)
+	reFooterRef     = regexp.MustCompile(`<w:footerReference[^>]*w:type="(\w+)"`)
+	reXmlns         = regexp.MustCompile(`\bxmlns:([\w.-]+)="([^"]*)"`)
+	reIgnorable     = regexp.MustCompile(`\bmc:Ignorable="([^"]*)"`)
+)
+
+// Relationship types that point at package-wide parts. They are merged
+// separately (styles, numbering, notes) or kept from the first document.
+var sharedRelTypes = map[string]bool{
+	"styles": true, "stylesWithEffects": true, "numbering": true, "settings": true,
+	"webSettings": true, "fontTable": true, "theme": true, "footnotes": true,
+	"endnotes": true, "comments": true, "commentsExtended": true, "commentsIds": true,
+	"commentsExtensible": true, "people": true, "glossaryDocument": true, "customXml": true,
+}
+
+// docxMerger appends DOCX bodies to a base package.
+//
+// The first document is the base: its settings, theme, fonts, document
+// defaults and final page setup stay. Every appended document becomes its own
+// section (its sectPr keeps page size, margins and header/footer references).
+// Styles with the same id and definition are shared, conflicting ones are
+// imported under a new id, and a source's default style maps to the base
+// default of the same type. List definitions, notes, bookmarks, media and other
+// related parts are copied under fresh ids so nothing collides.
+type docxMerger struct {
+	dst *docxPackage
+	ct  *contentTypes
+}
+
+// mergeSourceDocx is one appended document and its id mappings into dst.
+type mergeSourceDocx struct {
+	n      int // 1-based position, used for renamed style ids
+	pkg    *docxPackage
+	ct     *contentTypes
+	copied map[string]string // source part -> dst part
+
+	styles, nums, abstracts, footnotes, endnotes map[string]string
+}
+
+// mergeDocx concatenates DOCX files in order into one DOCX.
+func mergeDocx(parts [][]byte) ([]byte, error) {
+	dst, err := openDocxPackage(parts[0])
+	if err != nil {
+		return nil, withSourceIndex(0, status.Errorf(codes.InvalidArgument, "not a docx: %v", err))
+	}
+	if !dst.has(mainDocumentPart) {
+		return nil, withSourceIndex(0, status.Errorf(codes.InvalidArgument, "%s not found", mainDocumentPart))
+	}
+	ct, err := dst.contentTypes()
+	if err != nil {
+		return nil, withSourceIndex(0, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	m := &docxMerger{dst: dst, ct: ct}
+	for i, b := range parts[1:] {
+		if err := m.append(i+2, b); err != nil {
+			return nil, withSourceIndex(i+1, err)
+		}
+	}
+	m.renumberDrawings()
+	if err := dst.setContentTypes(m.ct); err != nil {
+		return nil, err
+	}
+	return dst.bytes()
+}
+
+func (m *docxMerger) append(n int, b []byte) error {
+	pkg, err := openDocxPackage(b)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "not a docx: %v", err)
+	}
+	doc, ok := pkg.get(mainDocumentPart)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "%s not found", mainDocumentPart)
+	}
+	ct, err := pkg.contentTypes()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if strings.Contains(string(doc), "<w:commentReference") {
+		return status.Error(codes.Unimplemented, "documents with comments cannot be appended")
+	}
+	src := &mergeSourceDocx{n: n, pkg: pkg, ct: ct, copied: map[string]string{}}
+
+	// urutan penting: id list dulu (dipakai styles), lalu styles (dipakai numbering)
+	if err := m.planNumbering(src); err != nil {
+		return err
+	}
+	if err := m.mergeStyles(src); err != nil {
+		return err
+	}
+	if err := m.mergeNumbering(src); err != nil {
+		return err
+	}
+	if src.footnotes, err = m.mergeNotes(src, "footnote", relTypeFootnotes); err != nil {
+		return err
+	}
+	if src.endnotes, err = m.mergeNotes(src, "endnote", relTypeEndnotes); err != nil {
+		return err
+	}
+	rids, err := m.importRels(src, mainDocumentPart, mainDocumentPart)
+	if err != nil {
+		return err
+	}
+
+	_, content, sect, _, err := splitBody(string(doc))
+	if err != nil {
+		return err
+	}
+	dstDoc, _ := m.dst.get(mainDocumentPart)
+	head, dstContent, dstSect, tail, err := splitBody(string(dstDoc))
+	if err != nil {
+		return err
+	}
+	content = string(src.rewrite([]byte(content), rids))
+	content = offsetBookmarks(content, maxAttr(dstContent, reBookmarkID)+1)
+	sect = string(src.rewrite([]byte(sect), rids))
+	if sect, err = m.completeSectionRefs(sect); err != nil {
+		return err
+	}
+
+	// section terakhir dokumen sebelumnya pindah ke paragraf penutupnya
+	if dstSect == "" {
+		dstSect = "<w:sectPr/>"
+	}
+	merged := head + dstContent + "<w:p><w:pPr>" + dstSect + "</w:pPr></w:p>" + startOnNewPage(content+sect) + tail
+	m.dst.set(mainDocumentPart, mergeRootNamespaces([]byte(merged), doc))
+	return nil
+}
+
+// rewrite maps style, list, note and relationship ids of a source part to dst.
+func (s *mergeSourceDocx) rewrite(b []byte, rids map[string]string) []byte {
+	x := string(b)
+	x = replaceRefs(x, reStyleRef, s.styles)
+	x = replaceRefs(x, reNumIDRef, s.nums)
+	x = replaceRefs(x, reFootnoteRef, s.footnotes)
+	x = replaceRefs(x, reEndnoteRef, s.endnotes)
+	x = replaceRefs(x, reRelIDAttr, rids)
+	return []byte(x)
+}
+
+// replaceRefs replaces group 2 of each re match (prefix, value, suffix) found in ids.
+func replaceRefs(s string, re *regexp.Regexp, ids map[string]string) string {
+	if len(ids) == 0 {
+		return s
+	}
+	return re.ReplaceAllStringFunc(s, func(match string) string {
+		sm := re.FindStringSubmatch(match)
+		if v, ok := ids[sm[2]]; ok {
+			return sm[1] + v + sm[3]
+		}
+		return match
+	})
+}
+
+// maxAttr returns the largest numeric group 2 of re in s, or -1.
+func maxAttr(s string, re *regexp.Regexp) int {
+	hi := -1
+	for _, sm := range re.FindAllStringSubmatch(s, -1) {
+		if v, err := strconv.Atoi(sm[2]); err == nil && v > hi {
+			hi = v
+		}
+	}
+	return hi
+}
+
+func offsetBookmarks(s string, offset int) string {
+	return reBookmarkID.ReplaceAllStringFunc(s, func(match string) string {
+		sm := reBookmarkID.FindStringSubmatch(match)
+		v, _ := strconv.Atoi(sm[2])
+		return sm[1] + strconv.Itoa(v+offset) + sm[3]
+	})
+}
+
+// splitBody splits document.xml around the body: everything up to and
+// including <w:body>, the block content, the final body-level sectPr (may be
+// empty) and everything from </w:body>.
+func splitBody(doc string) (head, content, sect, tail string, err error) {
+	start := strings.Index(doc, "<w:body")
+	end := strings.LastIndex(doc, "</w:body>")
+	if start < 0 || end < 0 {
+		return "", "", "", "", status.Error(codes.InvalidArgument, "document has no body")
+	}
+	start += strings.IndexByte(doc[start:], '>') + 1
+	head, content, tail = doc[:start], doc[start:end], doc[end:]
+
+	i := strings.LastIndex(content, "<w:sectPr")
+	if i < 0 {
+		return head, content, "", tail, nil
+	}
+	tagEnd := i + strings.IndexByte(content[i:], '>') + 1
+	sectEnd := tagEnd
+	if content[tagEnd-2] != '/' {
+		j := strings.Index(content[tagEnd:], "</w:sectPr>")
+		if j < 0 {
+			return "", "", "", "", status.Error(codes.InvalidArgument, "unterminated sectPr")
+		}
+		sectEnd = tagEnd + j + len("</w:sectPr>")
+	}
+	// sectPr di dalam paragraf bukan milik body
+	if strings.TrimSpace(content[sectEnd:]) != "" {
+		return head, content, "", tail, nil
+	}
+	return head, content[:i], content[i:sectEnd], tail, nil
+}
+
+// ---------- parts & relationships ----------
+
+// importRels copies the relationships of srcPart (and the parts they target)
+// to dstPart and returns old -> new relationship ids.
+func (m *docxMerger) importRels(src *mergeSourceDocx, srcPart, dstPart string) (map[string]string, error) {
+	rs, err := src.pkg.rels(srcPart)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ids := map[string]string{}
+	for _, r := range rs.Rels {
+		if sharedRelTypes[path.Base(r.Type)] {
+			continue
+		}
+		target := r.Target
+		if r.TargetMode != "External" {
+			name := resolveTarget(srcPart, r.Target)
+			if !src.pkg.has(name) {
+				continue
+			}
+			copied, err := m.copyPart(src, name)
+			if err != nil {
+				return nil, err
+			}
+			target = relativeTarget(dstPart, copied)
+		}
+		id, err := m.dst.addRelationship(dstPart, relationship{Type: r.Type, Target: target, TargetMode: r.TargetMode})
+		if err != nil {
+			return nil, err
+		}
+		ids[r.ID] = id
+	}
+	return ids, nil
+}
+
+// copyPart copies a source part (with its own relationships) into dst under
+// an unused name and returns that name.
+func (m *docxMerger) copyPart(src *mergeSourceDocx, name string) (string, error) {
+	if copied, ok := src.copied[name]; ok {
+		return copied, nil
+	}
+	dir, file := path.Split(name)
+	ext := path.Ext(file)
+	prefix := strings.TrimRight(strings.TrimSuffix(file, ext), "0123456789")
+	if prefix == "" {
+		prefix = "part"
+	}
+	copied := m.dst.uniqueName(strings.TrimSuffix(dir, "/"), prefix, strings.TrimPrefix(ext, "."))
+	src.copied[name] = copied
+
+	data, _ := src.pkg.get(name)
+	m.dst.set(copied, data) // reserve the name before recursing
+	m.addContentType(src, name, copied)
+
+	ids, err := m.importRels(src, name, copied)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(ext, ".xml") {
+		data = src.rewrite(data, ids)
+	}
+	m.dst.set(copied, data)
+	return copied, nil
+}
+
+// addContentType declares copied with the content type name has in the source.
+func (m *docxMerger) addContentType(src *mergeSourceDocx, name, copied string) {
+	typ := src.ct.contentTypeOf(name)
+	if typ == "" {
+		return
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	for _, d := range src.ct.Defaults {
+		if strings.EqualFold(d.Extension, ext) && d.ContentType == typ && m.ct.contentTypeOf(copied) == "" {
+			m.ct.ensureDefault(ext, typ)
+			return
+		}
+	}
+	if m.ct.contentTypeOf(copied) != typ {
+		m.ct.addOverride(copied, typ)
+	}
+}
+
+// packagePart returns the dst part of relType, creating it from empty when missing.
+func (m *docxMerger) packagePart(relType, name, contentType, empty string) (string, error) {
+	part, err := m.dst.relTarget(mainDocumentPart, relType)
+	if err != nil || part != "" {
+		return part, err
+	}
+	m.dst.set(name, []byte(xmlDeclaration+empty))
+	m.ct.addOverride(name, contentType)
+	if _, err := m.dst.addRel(mainDocumentPart, relType, relativeTarget(mainDocumentPart, name)); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ---------- styles ----------
+
+type styleDef struct {
+	id, typ, xml string
+	isDefault    bool
+}
+
+func parseStyles(b []byte) map[string]styleDef {
+	defs := map[string]styleDef{}
+	for _, x := range reStyleDef.FindAllString(string(b), -1) {
+		tag := x[:strings.IndexByte(x, '>')]
+		id := reStyleIDAttr.FindStringSubmatch(tag)
+		if id == nil {
+			continue
+		}
+		d := styleDef{id: id[2], xml: x, isDefault: reAttrDefault.MatchString(tag)}
+		if t := reAttrType.FindStringSubmatch(tag); t != nil {
+			d.typ = t[1]
+		}
+		defs[d.id] = d
+	}
+	return defs
+}
+
+// usedStyles returns the source style ids referenced from any source part
+// other than the styles part itself, closed over basedOn/next/link.
+func (src *mergeSourceDocx) usedStyles(stylesPart string, defs map[string]styleDef) []string {
+	used := map[string]bool{}
+	var queue []string
+	mark := func(s string) {
+		for _, sm := range reStyleRef.FindAllStringSubmatch(s, -1) {
+			if !used[sm[2]] {
+				used[sm[2]] = true
+				queue = append(queue, sm[2])
+			}
+		}
+	}
+	for _, name := range src.pkg.names {
+		if name != stylesPart && strings.HasSuffix(name, ".xml") {
+			data, _ := src.pkg.get(name)
+			mark(string(data))
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		mark(defs[id].xml)
+	}
+	ids := make([]string, 0, len(used))
+	for id := range used {
+		if _, ok := defs[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (m *docxMerger) mergeStyles(src *mergeSourceDocx) error {
+	srcPart, err := src.pkg.relTarget(mainDocumentPart, relTypeStyles)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	srcXML, ok := src.pkg.get(srcPart)
+	if srcPart == "" || !ok {
+		return nil
+	}
+	dstPart, err := m.packagePart(relTypeStyles, "word/styles.xml", ctStyles, `<w:styles xmlns:w="`+nsW+`"></w:styles>`)
+	if err != nil {
+		return err
+	}
+	dstXML, _ := m.dst.get(dstPart)
+
+	srcDefs := parseStyles(srcXML)
+	dstDefs := parseStyles(dstXML)
+	dstDefault := map[string]string{}
+	for _, d := range dstDefs {
+		if d.isDefault {
+			dstDefault[d.typ] = d.id
+		}
+	}
+
+	// style default ikut dokumen pertama, sama seperti docDefaults
+	defaults := map[string]string{}
+	for id, sd := range srcDefs {
+		if sd.isDefault && dstDefault[sd.typ] != "" {
+			defaults[id] = dstDefault[sd.typ]
+		}
+	}
+	sameAs := func(sd, dd styleDef) bool {
+		x := reAttrDefault.ReplaceAllString(replaceRefs(sd.xml, reStyleRef, defaults), "")
+		y := reAttrDefault.ReplaceAllString(dd.xml, "")
+		return strings.Join(strings.Fields(x), " ") == strings.Join(strings.Fields(y), " ")
+	}
+
+	used := src.usedStyles(srcPart, srcDefs)
+	src.styles = map[string]string{}
+	var imports []string
+	for _, id := range used {
+		sd := srcDefs[id]
+		dd, exists := dstDefs[id]
+		switch {
+		case defaults[id] != "":
+			src.styles[id] = defaults[id]
+		case !exists:
+			src.styles[id] = id
+			imports = append(imports, id)
+		case sameAs(sd, dd):
+			src.styles[id] = id
+		default:
+			newID := id
+			for i := src.n; dstDefs[newID].id != "" || srcDefs[newID].id != ""; i++ {
+				newID = fmt.Sprintf("%s-%d", id, i)
+			}
+			dstDefs[newID] = styleDef{id: newID}
+			src.styles[id] = newID
+			imports = append(imports, id)
+		}
+	}
+	if len(imports) == 0 {
+		return nil
+	}
+
+	var add strings.Builder
+	for _, id := range imports {
+		x := srcDefs[id].xml
+		if newID := src.styles[id]; newID != id {
+			x = replaceRefs(x, reStyleIDAttr, map[string]string{id: newID})
+			if sm := reStyleName.FindStringSubmatch(x); sm != nil {
+				x = strings.Replace(x, sm[0], sm[1]+fmt.Sprintf("%s (%d)", sm[2], src.n)+sm[3], 1)
+			}
+		}
+		x = reAttrDefault.ReplaceAllString(x, "")
+		add.WriteString(string(src.rewrite([]byte(x), nil)))
+	}
+	out := insertBefore(string(dstXML), "</w:styles>", add.String())
+	m.dst.set(dstPart, mergeRootNamespaces([]byte(out), srcXML))
+	return nil
+}
+
+// ---------- numbering ----------
+
+// planNumbering assigns dst ids to the source's list definitions.
+func (m *docxMerger) planNumbering(src *mergeSourceDocx) error {
+	srcPart, err := src.pkg.relTarget(mainDocumentPart, relTypeNumbering)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	srcXML, ok := src.pkg.get(srcPart)
+	if srcPart == "" || !ok {
+		return nil
+	}
+	var dstXML []byte
+	if dstPart, err := m.dst.relTarget(mainDocumentPart, relTypeNumbering); err != nil {
+		return err
+	} else if dstPart != "" {
+		dstXML, _ = m.dst.get(dstPart)
+	}
+
+	nextAbstract := maxAttr(string(dstXML), reAbstractIDDef) + 1
+	nextNum := 1
+	for _, x := range reNumDef.FindAllString(string(dstXML), -1) {
+		if sm := reNumIDDef.FindStringSubmatch(x); sm != nil {
+			if v, _ := strconv.Atoi(sm[2]); v >= nextNum {
+				nextNum = v + 1
+			}
+		}
+	}
+
+	src.abstracts = map[string]string{}
+	for _, x := range reAbstractNum.FindAllString(string(srcXML), -1) {
+		if sm := reAbstractIDDef.FindStringSubmatch(x); sm != nil {
+			src.abstracts[sm[2]] = strconv.Itoa(nextAbstract)
+			nextAbstract++
+		}
+	}
+	src.nums = map[string]string{}
+	for _, x := range reNumDef.FindAllString(string(srcXML), -1) {
+		if sm := reNumIDDef.FindStringSubmatch(x); sm != nil {
+			src.nums[sm[2]] = strconv.Itoa(nextNum)
+			nextNum++
+		}
+	}
+	return nil
+}
+
+func (m *docxMerger) mergeNumbering(src *mergeSourceDocx) error {
+	if len(src.abstracts) == 0 && len(src.nums) == 0 {
+		return nil
+	}
+	srcPart, _ := src.pkg.relTarget(mainDocumentPart, relTypeNumbering)
+	srcXML, _ := src.pkg.get(srcPart)
+	dstPart, err := m.packagePart(relTypeNumbering, "word/numbering.xml", ctNumbering, `<w:numbering xmlns:w="`+nsW+`"></w:numbering>`)
+	if err != nil {
+		return err
+	}
+	dstXML, _ := m.dst.get(dstPart)
+
+	var abstracts, nums strings.Builder
+	for _, x := range reAbstractNum.FindAllString(string(srcXML), -1) {
+		tagEnd := strings.IndexByte(x, '>')
+		sm := reAbstractIDDef.FindStringSubmatch(x[:tagEnd])
+		if sm == nil {
+			continue
+		}
+		newID := src.abstracts[sm[2]]
+		x = replaceRefs(x[:tagEnd], reAbstractIDDef, src.abstracts) + x[tagEnd:]
+		// nsid yang sama membuat Word menyambung list dari dokumen lain
+		x = reNsid.ReplaceAllStringFunc(x, func(match string) string {
+			sm := reNsid.FindStringSubmatch(match)
+			return sm[1] + fmt.Sprintf("%08X", crc32.ChecksumIEEE([]byte(sm[2]+"/"+newID))) + sm[3]
+		})
+		// picture bullet tidak ikut disalin; level jatuh ke lvlText
+		x = rePicBullet.ReplaceAllString(x, "")
+		abstracts.WriteString(string(src.rewrite([]byte(x), nil)))
+	}
+	for _, x := range reNumDef.FindAllString(string(srcXML), -1) {
+		tagEnd := strings.IndexByte(x, '>')
+		x = replaceRefs(x[:tagEnd], reNumIDDef, src.nums) + x[tagEnd:]
+		x = replaceRefs(x, reAbstractIDRef, src.abstracts)
+		nums.WriteString(x)
+	}
+
+	// skema: semua abstractNum sebelum num
+	out := string(dstXML)
+	if loc := reNumDef.FindStringIndex(out); loc != nil {
+		out = out[:loc[0]] + abstracts.String() + out[loc[0]:]
+	} else {
+		out = insertBefore(out, "</w:numbering>", abstracts.String())
+	}
+	if i := strings.Index(out, "<w:numIdMacAtCleanup"); i >= 0 {
+		out = out[:i] + nums.String() + out[i:]
+	} else {
+		out = insertBefore(out, "</w:numbering>", nums.String())
+	}
+	m.dst.set(dstPart, mergeRootNamespaces([]byte(out), srcXML))
+	return nil
+}
+
+// ---------- footnotes / endnotes ----------
+
+// mergeNotes appends the source's footnotes or endnotes (kind) under fresh
+// ids and returns old -> new ids. Separator notes are kept from dst.
+func (m *docxMerger) mergeNotes(src *mergeSourceDocx, kind, relType string) (map[string]string, error) {
+	srcPart, err := src.pkg.relTarget(mainDocumentPart, relType)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	srcXML, ok := src.pkg.get(srcPart)
+	if srcPart == "" || !ok {
+		return nil, nil
+	}
+	dstPart, err := m.dst.relTarget(mainDocumentPart, relType)
+	if err != nil {
+		return nil, err
+	}
+	if dstPart == "" {
+		// dokumen pertama belum punya part ini: salin utuh, id tetap
+		copied, err := m.copyPart(src, srcPart)
+		if err != nil {
+			return nil, err
+		}
+		_, err = m.dst.addRel(mainDocumentPart, relType, relativeTarget(mainDocumentPart, copied))
+		return nil, err
+	}
+	dstXML, _ := m.dst.get(dstPart)
+
+	next := 1
+	for _, x := range reNoteDef.FindAllString(string(dstXML), -1) {
+		if sm := reNoteID.FindStringSubmatch(x[:strings.IndexByte(x, '>')]); sm != nil {
+			if v, _ := strconv.Atoi(sm[2]); v >= next {
+				next = v + 1
+			}
+		}
+	}
+	rids, err := m.importRels(src, srcPart, dstPart)
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]string{}
+	var add strings.Builder
+	for _, x := range reNoteDef.FindAllString(string(srcXML), -1) {
+		tagEnd := strings.IndexByte(x, '>')
+		tag := x[:tagEnd]
+		if t := reAttrType.FindStringSubmatch(tag); t != nil && t[1] != "normal" {
+			continue
+		}
+		sm := reNoteID.FindStringSubmatch(tag)
+		if sm == nil {
+			continue
+		}
+		ids[sm[2]] = strconv.Itoa(next)
+		next++
+		x = replaceRefs(tag, reNoteID, ids) + x[tagEnd:]
+		add.WriteString(string(src.rewrite([]byte(x), rids)))
+	}
+	out := insertBefore(string(dstXML), "</w:"+kind+"s>", add.String())
+	m.dst.set(dstPart, mergeRootNamespaces([]byte(out), srcXML))
+	return ids, nil
+}
+
+// ---------- sections ----------
+
+// startOnNewPage makes the first section of an appended body start on a new
+// page even if its source declared a continuous section.
+func startOnNewPage(body string) string {
+	i := strings.Index(body, "<w:sectPr")
+	if i < 0 {
+		return body
+	}
+	j := strings.Index(body[i:], "</w:sectPr>")
+	if j < 0 {
+		return body
+	}
+	sect := strings.Replace(body[i:i+j], `<w:type w:val="continuous"/>`, `<w:type w:val="nextPage"/>`, 1)
+	return body[:i] + sect + body[i+j:]
+}
+
+// completeSectionRefs gives an appended section explicit (empty) header and
+// footer references where it has none, so it does not inherit the previous
+// document's header or footer.
+func (m *docxMerger) completeSectionRefs(sect string) (string, error) {
+	if sect == "" {
+		sect = "<w:sectPr/>"
+	}
+	rs, err := m.dst.rels(mainDocumentPart)
+	if err != nil {
+		return "", err
+	}
+	kinds := []struct {
+		elem, root, prefix, relType, contentType string
+		ref                                      *regexp.Regexp
+	}{
+		{"headerReference", "hdr", "header", relTypeHeader, ctHeader, reHeaderRef},
+		{"footerReference", "ftr", "footer", relTypeFooter, ctFooter, reFooterRef},
+	}
+	types := []string{"default"}
+	if reTitlePg.MatchString(sect) {
+		types = append(types, "first")
+	}
+
+	var refs strings.Builder
+	for _, k := range kinds {
+		inUse := false
+		for _, r := range rs.Rels {
+			inUse = inUse || r.Type == k.relType
+		}
+		if !inUse {
+			continue
+		}
+		have := map[string]bool{}
+		for _, sm := range k.ref.FindAllStringSubmatch(sect, -1) {
+			have[sm[1]] = true
+		}
+		for _, typ := range types {
+			if have[typ] {
+				continue
+			}
+			part := m.dst.uniqueName("word", k.prefix, "xml")
+			m.dst.set(part, []byte(xmlDeclaration+`<w:`+k.root+` xmlns:w="`+nsW+`"><w:p/></w:`+k.root+`>`))
+			m.ct.addOverride(part, k.contentType)
+			id, err := m.dst.addRel(mainDocumentPart, k.relType, relativeTarget(mainDocumentPart, part))
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&refs, `<w:%s w:type="%s" r:id="%s"/>`, k.elem, typ, id)
+		}
+	}
+	if refs.Len() == 0 {
+		return sect, nil
+	}
+	return string(insertAfterRootStart([]byte(sect), refs.String())), nil
+}
+
+// renumberDrawings gives every wp:docPr in the package a unique id; Word
+// reports duplicate drawing ids as unreadable content.
+func (m *docxMerger) renumberDrawings() {
+	next := 1
+	for _, name := range m.dst.names {
+		if !strings.HasPrefix(name, "word/") || !strings.HasSuffix(name, ".xml") {
+			continue
+		}
+		data, _ := m.dst.get(name)
+		if !reDocPrID.Match(data) {
+			continue
+		}
+		out := reDocPrID.ReplaceAllStringFunc(string(data), func(match string) string {
+			sm := reDocPrID.FindStringSubmatch(match)
+			next++
+			return sm[1] + strconv.Itoa(next-1) + sm[3]
+		})
+		m.dst.set(name, []byte(out))
+	}
+}
+
+// ---------- XML helpers ----------
+
+func insertBefore(s, marker, content string) string {
+	i := strings.LastIndex(s, marker)
+	if i < 0 {
+		return s
+	}
+	return s[:i] + content + s[i:]
+}
+
+// mergeRootNamespaces adds the namespace declarations (and mc:Ignorable
+// prefixes) of src's root element to dst's root, so fragments copied from
+// src stay well-formed.
+func mergeRootNamespaces(dst, src []byte) []byte {
+	ss, se := rootStartTag(src)
+	if ss < 0 {
+		return dst
+	}
+	tag := src[ss:se]
+	ns := map[string]string{}
+	for _, sm := range reXmlns.FindAllSubmatch(tag, -1) {
+		ns[string(sm[1])] = string(sm[2])
+	}
+	dst = ensureNamespaces(dst, ns)
+
+	ign := reIgnorable.FindSubmatch(tag)
+	if ign == nil {
+		return dst
+	}
+	ds, de := rootStartTag(dst)
+	if ds < 0 {
+		return dst
+	}
+	dtag := string(dst[ds:de])
+	var have []string
+	loc := reIgnorable.FindStringSubmatchIndex(dtag)
+	if loc != nil {
+		have = strings.Fields(dtag[loc[2]:loc[3]])
+	}
+	merged := append([]string(nil), have...)
+	for _, p := range strings.Fields(string(ign[1])) {
+		if !slices.Contains(merged, p) {
+			merged = append(merged, p)
+		}
+	}
+	if len(merged) == len(have) {
+		return dst
+	}
+	attr := `mc:Ignorable="` + strings.Join(merged, " ") + `"`
+	if loc != nil {
+		dtag = dtag[:loc[0]] + attr + dtag[loc[1]:]
+	} else {
+		insert := len(dtag) - 1
+		if dtag[insert-1] == '/' {
+			insert--
+		}
+		dtag = dtag[:insert] + " " + attr + dtag[insert:]
+	}
+	return []byte(string(dst[:ds]) + dtag + string(dst[de:]))
+}
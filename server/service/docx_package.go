@@ -0,0 +1,365 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	relTypeHeader = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/header"
+	relTypeFooter = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer"
+	relTypeImage  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+
+	relTypeStyles    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	relTypeNumbering = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering"
+	relTypeFootnotes = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes"
+	relTypeEndnotes  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/endnotes"
+
+	ctHeader    = "application/vnd.openxmlformats-officedocument.wordprocessingml.header+xml"
+	ctFooter    = "application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"
+	ctStyles    = "application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"
+	ctNumbering = "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"
+
+	contentTypesPart = "[Content_Types].xml"
+	mainDocumentPart = "word/document.xml"
+)
+
+// docxPackage is a DOCX (OPC zip) held in memory for part-level edits.
+// Entry order is kept so rewritten files stay close to the original.
+type docxPackage struct {
+	names []string
+	files map[string][]byte
+}
+
+func openDocxPackage(b []byte) (*docxPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	p := &docxPackage{files: map[string][]byte{}}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		p.set(f.Name, data)
+	}
+	return p, nil
+}
+
+func (p *docxPackage) get(name string) ([]byte, bool) {
+	b, ok := p.files[name]
+	return b, ok
+}
+
+func (p *docxPackage) has(name string) bool {
+	_, ok := p.files[name]
+	return ok
+}
+
+func (p *docxPackage) set(name string, data []byte) {
+	if _, ok := p.files[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.files[name] = data
+}
+
+func (p *docxPackage) remove(name string) {
+	if _, ok := p.files[name]; !ok {
+		return
+	}
+	delete(p.files, name)
+	for i, n := range p.names {
+		if n == name {
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// uniqueName returns dir/prefixN.ext for the first N not used in the package.
+func (p *docxPackage) uniqueName(dir, prefix, ext string) string {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s/%s%d.%s", dir, prefix, i, ext)
+		if !p.has(name) {
+			return name
+		}
+	}
+}
+
+func (p *docxPackage) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range p.names {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(p.files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ---------- relationships ----------
+
+type relationship struct {
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:"Type,attr"`
+	Target     string `xml:"Target,attr"`
+	TargetMode string `xml:"TargetMode,attr,omitempty"`
+}
+
+type relationships struct {
+	XMLName xml.Name       `xml:"http://schemas.openxmlformats.org/package/2006/relationships Relationships"`
+	Rels    []relationship `xml:"Relationship"`
+}
+
+// relsPartName returns the relationships part for part, e.g.
+// word/document.xml -> word/_rels/document.xml.rels.
+func relsPartName(part string) string {
+	dir, file := path.Split(part)
+	return dir + "_rels/" + file + ".rels"
+}
+
+// resolveTarget turns a relationship target relative to part into a package part name.
+func resolveTarget(part, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(part), target)
+}
+
+func (p *docxPackage) rels(part string) (*relationships, error) {
+	rs := &relationships{}
+	b, ok := p.get(relsPartName(part))
+	if !ok {
+		return rs, nil
+	}
+	if err := xml.Unmarshal(b, rs); err != nil {
+		return nil, fmt.Errorf("%s: %w", relsPartName(part), err)
+	}
+	return rs, nil
+}
+
+func (p *docxPackage) setRels(part string, rs *relationships) error {
+	b, err := xml.Marshal(rs)
+	if err != nil {
+		return err
+	}
+	p.set(relsPartName(part), append([]byte(xml.Header), b...))
+	return nil
+}
+
+// nextID returns an unused relationship id.
+func (rs *relationships) nextID() string {
+	used := map[string]bool{}
+	for _, r := range rs.Rels {
+		used[r.ID] = true
+	}
+	for i := len(rs.Rels) + 1; ; i++ {
+		id := fmt.Sprintf("rId%d", i)
+		if !used[id] {
+			return id
+		}
+	}
+}
+
+// addRel adds a relationship from part to target (relative to part) and returns its id.
+func (p *docxPackage) addRel(part, relType, target string) (string, error) {
+	return p.addRelationship(part, relationship{Type: relType, Target: target})
+}
+
+// addRelationship adds r to part under a fresh id and returns the id.
+func (p *docxPackage) addRelationship(part string, r relationship) (string, error) {
+	rs, err := p.rels(part)
+	if err != nil {
+		return "", err
+	}
+	r.ID = rs.nextID()
+	rs.Rels = append(rs.Rels, r)
+	return r.ID, p.setRels(part, rs)
+}
+
+// relTarget returns the part targeted by the first internal relationship of
+// relType from part, or "" if there is none.
+func (p *docxPackage) relTarget(part, relType string) (string, error) {
+	rs, err := p.rels(part)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range rs.Rels {
+		if r.Type == relType && r.TargetMode != "External" {
+			return resolveTarget(part, r.Target), nil
+		}
+	}
+	return "", nil
+}
+
+// relativeTarget returns name as a relationship target from part.
+func relativeTarget(part, name string) string {
+	if dir := path.Dir(part) + "/"; strings.HasPrefix(name, dir) {
+		return strings.TrimPrefix(name, dir)
+	}
+	return "/" + name
+}
+
+// ---------- content types ----------
+
+type ctDefault struct {
+	Extension   string `xml:"Extension,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+type ctOverride struct {
+	PartName    string `xml:"PartName,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+type contentTypes struct {
+	XMLName   xml.Name     `xml:"http://schemas.openxmlformats.org/package/2006/content-types Types"`
+	Defaults  []ctDefault  `xml:"Default"`
+	Overrides []ctOverride `xml:"Override"`
+}
+
+func (p *docxPackage) contentTypes() (*contentTypes, error) {
+	ct := &contentTypes{}
+	b, ok := p.get(contentTypesPart)
+	if !ok {
+		return nil, fmt.Errorf("%s not found", contentTypesPart)
+	}
+	if err := xml.Unmarshal(b, ct); err != nil {
+		return nil, fmt.Errorf("%s: %w", contentTypesPart, err)
+	}
+	return ct, nil
+}
+
+func (p *docxPackage) setContentTypes(ct *contentTypes) error {
+	b, err := xml.Marshal(ct)
+	if err != nil {
+		return err
+	}
+	p.set(contentTypesPart, append([]byte(xml.Header), b...))
+	return nil
+}
+
+// contentTypeOf returns the declared content type of part.
+func (ct *contentTypes) contentTypeOf(part string) string {
+	for _, o := range ct.Overrides {
+		if strings.TrimPrefix(o.PartName, "/") == part {
+			return o.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(part), ".")
+	for _, d := range ct.Defaults {
+		if strings.EqualFold(d.Extension, ext) {
+			return d.ContentType
+		}
+	}
+	return ""
+}
+
+func (ct *contentTypes) addOverride(part, contentType string) {
+	ct.Overrides = append(ct.Overrides, ctOverride{PartName: "/" + part, ContentType: contentType})
+}
+
+func (ct *contentTypes) ensureDefault(ext, contentType string) {
+	for _, d := range ct.Defaults {
+		if strings.EqualFold(d.Extension, ext) {
+			return
+		}
+	}
+	ct.Defaults = append(ct.Defaults, ctDefault{Extension: ext, ContentType: contentType})
+}
+
+// ---------- XML helpers ----------
+
+// ensureNamespaces adds xmlns declarations missing from the root element of an XML part.
+func ensureNamespaces(part []byte, ns map[string]string) []byte {
+	start, end := rootStartTag(part)
+	if start < 0 {
+		return part
+	}
+	tag := string(part[start:end])
+	prefixes := make([]string, 0, len(ns))
+	for prefix := range ns {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	var add strings.Builder
+	for _, prefix := range prefixes {
+		if !strings.Contains(tag, "xmlns:"+prefix+"=") {
+			fmt.Fprintf(&add, ` xmlns:%s="%s"`, prefix, ns[prefix])
+		}
+	}
+	if add.Len() == 0 {
+		return part
+	}
+	insert := end - 1 // before '>'
+	if part[insert-1] == '/' {
+		insert--
+	}
+	out := make([]byte, 0, len(part)+add.Len())
+	out = append(out, part[:insert]...)
+	out = append(out, add.String()...)
+	return append(out, part[insert:]...)
+}
+
+// rootStartTag returns the byte range of the root element's start tag.
+func rootStartTag(part []byte) (int, int) {
+	i := 0
+	for {
+		j := bytes.IndexByte(part[i:], '<')
+		if j < 0 {
+			return -1, -1
+		}
+		i += j
+		if i+1 < len(part) && part[i+1] != '?' && part[i+1] != '!' {
+			break
+		}
+		i++
+	}
+	k := bytes.IndexByte(part[i:], '>')
+	if k < 0 {
+		return -1, -1
+	}
+	return i, i + k + 1
+}
+
+// insertAfterRootStart inserts content right after the root element's start tag.
+// A self-closing root is expanded first.
+func insertAfterRootStart(part []byte, content string) []byte {
+	start, end := rootStartTag(part)
+	if start < 0 {
+		return part
+	}
+	if part[end-2] == '/' {
+		name := strings.Fields(string(part[start+1 : end-2]))[0]
+		return []byte(string(part[:end-2]) + ">" + content + "</" + name + ">" + string(part[end:]))
+	}
+	return []byte(string(part[:end]) + content + string(part[end:]))
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+)
+
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+type outputFormat struct {
+	ext         string
+	contentType string
+	// filter is the LibreOffice --convert-to target ("ext:FilterName:options");
+	// empty for formats produced natively in Go.
+	filter string
+	// raster formats are converted to PDF and the single page rasterized;
+	// LibreOffice's own image export silently drops every page but the first.
+	raster bool
+}
+
+var outputFormats = map[docgenpb.OutputFormat]outputFormat{
+	docgenpb.OutputFormat_OUTPUT_FORMAT_PDF:  {ext: "pdf", contentType: "application/pdf", filter: "pdf"},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_DOCX: {ext: "docx", contentType: docxContentType},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_ODT:  {ext: "odt", contentType: "application/vnd.oasis.opendocument.text", filter: "odt:writer8"},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_RTF:  {ext: "rtf", contentType: "application/rtf", filter: "rtf:Rich Text Format"},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_HTML: {ext: "html", contentType: "text/html; charset=utf-8", filter: "html:HTML (StarWriter):EmbedImages"},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_TXT:  {ext: "txt", contentType: "text/plain; charset=utf-8"},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_PNG:  {ext: "png", contentType: "image/png", filter: "pdf", raster: true},
+	docgenpb.OutputFormat_OUTPUT_FORMAT_JPEG: {ext: "jpg", contentType: "image/jpeg", filter: "pdf", raster: true},
+}
+
+// outputFilename applies the default name and extension to a filename hint.
+func outputFilename(hint, ext string) string {
+	if hint == "" {
+		return "result." + ext
+	}
+	if !strings.HasSuffix(strings.ToLower(hint), "."+ext) {
+		return hint + "." + ext
+	}
+	return hint
+}
+
+// docxToText extracts the body text of a DOCX, one line per paragraph.
+func docxToText(docxBytes []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(docxBytes), int64(len(docxBytes)))
+	if err != nil {
+		return nil, err
+	}
+	var part *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			part = f
+			break
+		}
+	}
+	if part == nil {
+		return nil, fmt.Errorf("word/document.xml not found")
+	}
+	rc, err := part.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var out bytes.Buffer
+	inText := false
+	decoder := xml.NewDecoder(rc)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				out.WriteByte('\t')
+			case "br", "cr":
+				out.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				out.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				out.Write(t)
+			}
+		}
+	}
+	return out.Bytes(), nil
+}
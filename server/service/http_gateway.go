@@ -0,0 +1,428 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultMaxHTTPBody caps request bodies accepted by the gateway (template +
+// data) until SetMaxBodySize is called.
+const defaultMaxHTTPBody = 32 << 20
+
+// multipartMemory is how much of a multipart body is kept in memory; the rest
+// goes to temporary files.
+const multipartMemory = 32 << 20
+
+// Timeouts of the gateway's http.Server, so slow clients cannot hold
+// connections open. There is no write timeout: a conversion may take as long
+// as the sandbox wall time.
+const (
+	httpReadHeaderTimeout = 10 * time.Second
+	httpReadTimeout       = 5 * time.Minute
+	httpIdleTimeout       = 2 * time.Minute
+)
+
+var (
+	jsonIn  = protojson.UnmarshalOptions{DiscardUnknown: true}
+	jsonOut = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// HTTPGateway exposes DocService over HTTP for clients that cannot speak gRPC.
+// Every call goes through the same unary interceptor chain as the gRPC server,
+// so auth, rate limiting and logging behave the same on both transports.
+//
+//	POST /v1/placeholders         JSON TemplateRequest  -> JSON PlaceholderResponse
+//	POST /v1/generate/pdf         JSON GenerateRequest  -> JSON GenerateResponse
+//	POST /v1/generate/docx        JSON GenerateRequest  -> JSON GenerateResponse
+//	POST /v1/generate/pdf/file    multipart form        -> raw PDF
+//	POST /v1/generate/docx/file   multipart form        -> raw DOCX
+//	POST /v1/generate             JSON GenerateRequest  -> JSON GenerateResponse (any output_format)
+//	POST /v1/generate/file        multipart form        -> raw file in output_format
+//	POST /v1/merge                JSON MergeRequest     -> JSON GenerateResponse
+//	POST /v1/verify/pdf           JSON VerifyPDFRequest or raw application/pdf -> JSON VerifyPDFResponse
+//	POST /v1/audit/query          JSON AuditQuery       -> JSON AuditQueryResponse
+//	GET  /v1/documents/{code}                           -> JSON DocumentInfo
+//	POST /v1/preview              JSON PreviewRequest   -> JSON PreviewResponse
+//	POST /v1/templates/validate   JSON TemplateRequest  -> JSON ValidateTemplateResponse
+//	POST /v1/admin/keys/{create,rotate,revoke,list}     -> KeyAdmin, see RegisterKeyAdmin
+//
+// Bytes fields (template, content) are base64 in JSON bodies. The multipart
+// endpoints take a "template" file part, an optional "data" part holding a JSON
+// object of strings, and optional "filename_hint" and "output_format" fields
+// (e.g. "odt" or "OUTPUT_FORMAT_ODT").
+type HTTPGateway struct {
+	svc         docgenpb.DocServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	mux         *http.ServeMux
+	maxBody     int64
+}
+
+func NewHTTPGateway(svc docgenpb.DocServiceServer, interceptor grpc.UnaryServerInterceptor) *HTTPGateway {
+	g := &HTTPGateway{svc: svc, interceptor: interceptor, mux: http.NewServeMux(), maxBody: defaultMaxHTTPBody}
+
+	g.mux.HandleFunc("POST /v1/placeholders", handleUnary(g, docgenpb.DocService_GetPlaceholders_FullMethodName, svc.GetPlaceholders))
+	g.mux.HandleFunc("POST /v1/generate/pdf", g.handleGenerateJSON(docgenpb.DocService_GeneratePDF_FullMethodName, svc.GeneratePDF))
+	g.mux.HandleFunc("POST /v1/generate/docx", g.handleGenerateJSON(docgenpb.DocService_GenerateDocx_FullMethodName, svc.GenerateDocx))
+	g.mux.HandleFunc("POST /v1/generate/pdf/file", g.handleGenerateFile(docgenpb.DocService_GeneratePDF_FullMethodName, svc.GeneratePDF))
+	g.mux.HandleFunc("POST /v1/generate/docx/file", g.handleGenerateFile(docgenpb.DocService_GenerateDocx_FullMethodName, svc.GenerateDocx))
+	g.mux.HandleFunc("POST /v1/generate", g.handleGenerateJSON(docgenpb.DocService_Generate_FullMethodName, svc.Generate))
+	g.mux.HandleFunc("POST /v1/generate/file", g.handleGenerateFile(docgenpb.DocService_Generate_FullMethodName, svc.Generate))
+	g.mux.HandleFunc("POST /v1/merge", handleUnary(g, docgenpb.DocService_MergeDocuments_FullMethodName, svc.MergeDocuments))
+	g.mux.HandleFunc("POST /v1/verify/pdf", g.handleVerifyPDF)
+	g.mux.HandleFunc("POST /v1/audit/query", handleUnary(g, docgenpb.DocService_QueryAudit_FullMethodName, svc.QueryAudit))
+	g.mux.HandleFunc("GET /v1/documents/{code}", g.handleLookupDocument)
+	g.mux.HandleFunc("POST /v1/preview", handleUnary(g, docgenpb.DocService_RenderPreview_FullMethodName, svc.RenderPreview))
+	g.mux.HandleFunc("POST /v1/templates/validate", handleUnary(g, docgenpb.DocService_ValidateTemplate_FullMethodName, svc.ValidateTemplate))
+	return g
+}
+
+// RegisterKeyAdmin adds the KeyAdmin RPCs under /v1/admin/keys/.
+func (g *HTTPGateway) RegisterKeyAdmin(a docgenpb.KeyAdminServer) {
+	g.mux.HandleFunc("POST /v1/admin/keys/create", handleUnary(g, docgenpb.KeyAdmin_CreateAPIKey_FullMethodName, a.CreateAPIKey))
+	g.mux.HandleFunc("POST /v1/admin/keys/rotate", handleUnary(g, docgenpb.KeyAdmin_RotateAPIKey_FullMethodName, a.RotateAPIKey))
+	g.mux.HandleFunc("POST /v1/admin/keys/revoke", handleUnary(g, docgenpb.KeyAdmin_RevokeAPIKey_FullMethodName, a.RevokeAPIKey))
+	g.mux.HandleFunc("POST /v1/admin/keys/list", handleUnary(g, docgenpb.KeyAdmin_ListAPIKeys_FullMethodName, a.ListAPIKeys))
+}
+
+// SetMaxBodySize caps request bodies, normally at the gRPC max message size.
+// JSON bodies carry files as base64, so they fit about 3/4 of that.
+func (g *HTTPGateway) SetMaxBodySize(n int64) {
+	g.maxBody = n
+}
+
+func (g *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, g.maxBody)
+	g.mux.ServeHTTP(w, r)
+}
+
+func newHTTPServer(addr string, g *HTTPGateway) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           g,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+		ReadTimeout:       httpReadTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	}
+}
+
+// ListenAndServeHTTP runs the gateway on addr (blocking).
+func ListenAndServeHTTP(addr string, g *HTTPGateway) error {
+	return newHTTPServer(addr, g).ListenAndServe()
+}
+
+// ListenAndServeHTTPS is ListenAndServeHTTP over TLS; certificates come from
+// cfg (e.g. TLSReloader.ServerConfig).
+func ListenAndServeHTTPS(addr string, cfg *tls.Config, g *HTTPGateway) error {
+	srv := newHTTPServer(addr, g)
+	srv.TLSConfig = cfg
+	return srv.ListenAndServeTLS("", "")
+}
+
+type generateFunc func(context.Context, *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error)
+
+// handleUnary serves a JSON-in/JSON-out RPC.
+func handleUnary[Req, Resp proto.Message](g *HTTPGateway, method string, call func(context.Context, Req) (Resp, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req Req
+		req = req.ProtoReflect().New().Interface().(Req)
+		if err := decodeJSON(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err := g.invoke(w, r, method, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(ctx, req.(Req))
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeProto(w, resp.(proto.Message))
+	}
+}
+
+// handleVerifyPDF also takes the PDF itself as the body, so a returned file can
+// be checked with a plain upload.
+func (g *HTTPGateway) handleVerifyPDF(w http.ResponseWriter, r *http.Request) {
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/pdf" {
+		handleUnary(g, docgenpb.DocService_VerifyPDF_FullMethodName, g.svc.VerifyPDF)(w, r)
+		return
+	}
+	pdf, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, bodyError(err))
+		return
+	}
+	resp, err := g.invoke(w, r, docgenpb.DocService_VerifyPDF_FullMethodName, &docgenpb.VerifyPDFRequest{Pdf: pdf}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.svc.VerifyPDF(ctx, req.(*docgenpb.VerifyPDFRequest))
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeProto(w, resp.(proto.Message))
+}
+
+func (g *HTTPGateway) handleLookupDocument(w http.ResponseWriter, r *http.Request) {
+	req := &docgenpb.LookupDocumentRequest{Code: r.PathValue("code")}
+	resp, err := g.invoke(w, r, docgenpb.DocService_LookupDocument_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.svc.LookupDocument(ctx, req.(*docgenpb.LookupDocumentRequest))
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeProto(w, resp.(proto.Message))
+}
+
+func (g *HTTPGateway) handleGenerateJSON(method string, fn generateFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &docgenpb.GenerateRequest{}
+		if err := decodeJSON(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err := g.generate(w, r, method, fn, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeProto(w, resp)
+	}
+}
+
+func (g *HTTPGateway) handleGenerateFile(method string, fn generateFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeMultipart(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err := g.generate(w, r, method, fn, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", resp.GetContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": resp.GetFilename()}))
+		w.Header().Set("Content-Length", fmt.Sprint(len(resp.GetContent())))
+		_, _ = w.Write(resp.GetContent())
+	}
+}
+
+func (g *HTTPGateway) generate(w http.ResponseWriter, r *http.Request, method string, fn generateFunc, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+	resp, err := g.invoke(w, r, method, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return fn(ctx, req.(*docgenpb.GenerateRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*docgenpb.GenerateResponse), nil
+}
+
+// invoke runs handler behind the gRPC unary interceptor chain. Header and
+// trailer metadata set by the interceptors (rate limit, quota, idempotency)
+// become response headers.
+func (g *HTTPGateway) invoke(w http.ResponseWriter, r *http.Request, method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{Server: g.svc, FullMethod: method}
+	st := &gatewayStream{method: method}
+	ctx := grpc.NewContextWithServerTransportStream(incomingContext(r), st)
+	resp, err := g.interceptor(ctx, req, info, handler)
+	for _, md := range []metadata.MD{st.header, st.trailer} {
+		for k, vals := range md {
+			for _, v := range vals {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+	return resp, err
+}
+
+// gatewayStream collects what handlers pass to grpc.SetHeader and
+// grpc.SetTrailer during a gateway call.
+type gatewayStream struct {
+	method          string
+	header, trailer metadata.MD
+}
+
+func (s *gatewayStream) Method() string { return s.method }
+
+func (s *gatewayStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *gatewayStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *gatewayStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// incomingContext maps HTTP headers to gRPC metadata (x-api-key, authorization, ...)
+// and the remote address to a peer, so interceptors see what a gRPC call would carry.
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for k, vals := range r.Header {
+		md.Append(strings.ToLower(k), vals...)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	pr := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		pr.Addr = addr
+	}
+	if r.TLS != nil {
+		// sertifikat klien ikut, sama seperti koneksi gRPC
+		pr.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	if pr.Addr != nil || pr.AuthInfo != nil {
+		ctx = peer.NewContext(ctx, pr)
+	}
+	return ctx
+}
+
+func decodeJSON(r *http.Request, m proto.Message) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return bodyError(err)
+	}
+	if err := jsonIn.Unmarshal(body, m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid json body: %v", err)
+	}
+	return nil
+}
+
+func decodeMultipart(r *http.Request) (*docgenpb.GenerateRequest, error) {
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		return nil, bodyError(err)
+	}
+	f, _, err := r.FormFile("template")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "missing template file part")
+	}
+	defer f.Close()
+	tpl, err := io.ReadAll(f)
+	if err != nil {
+		return nil, bodyError(err)
+	}
+
+	req := &docgenpb.GenerateRequest{
+		Template:     tpl,
+		FilenameHint: r.FormValue("filename_hint"),
+	}
+	if v := r.FormValue("output_format"); v != "" {
+		name := strings.ToUpper(v)
+		if !strings.HasPrefix(name, "OUTPUT_FORMAT_") {
+			name = "OUTPUT_FORMAT_" + name
+		}
+		f, ok := docgenpb.OutputFormat_value[name]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown output_format %q", v)
+		}
+		req.OutputFormat = docgenpb.OutputFormat(f)
+	}
+	if raw, err := formValueOrFile(r, "data"); err != nil {
+		return nil, err
+	} else if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &req.Data); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "data must be a json object of strings: %v", err)
+		}
+	}
+	return req, nil
+}
+
+// formValueOrFile reads a form field sent either as a plain value or as a file part.
+func formValueOrFile(r *http.Request, name string) ([]byte, error) {
+	if v := r.FormValue(name); v != "" {
+		return []byte(v), nil
+	}
+	f, _, err := r.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, bodyError(err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, bodyError(err)
+	}
+	return b, nil
+}
+
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return status.Errorf(codes.ResourceExhausted, "request body exceeds %d bytes", tooLarge.Limit)
+	}
+	return status.Errorf(codes.InvalidArgument, "read body: %v", err)
+}
+
+func writeProto(w http.ResponseWriter, m proto.Message) {
+	b, err := jsonOut.Marshal(m)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"code":    st.Code().String(),
+		"message": st.Message(),
+	})
+}
+
+// httpStatusFromCode follows the grpc-gateway mapping.
+func httpStatusFromCode(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
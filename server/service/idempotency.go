@@ -0,0 +1,172 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const idempotencyHeader = "idempotency-key"
+
+type idemEntry struct {
+	key     string
+	payload [32]byte
+	done    chan struct{} // closed once resp/err are set
+	resp    interface{}
+	err     error
+	expires time.Time
+	size    int64
+	elem    *list.Element // in order once done
+}
+
+// idemEntryOverhead is counted for every remembered call on top of the
+// response, so that many tiny responses are bounded too.
+const idemEntryOverhead = 256
+
+// IdempotencyStore remembers the outcome of unary calls that carry an
+// idempotency key (metadata "idempotency-key" or the request's
+// idempotency_key field), per caller, for window. A repeated call with the same
+// key and payload gets the stored response; the same key with a different
+// payload is rejected with AlreadyExists. Failed calls are not remembered so
+// the client can retry them. Stored responses are bounded by maxBytes; the
+// oldest are forgotten first, and a response larger than maxBytes is not
+// remembered at all.
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	window    time.Duration
+	maxBytes  int64
+	size      int64
+	entries   map[string]*idemEntry
+	order     *list.List // done entries, oldest first
+	lastSweep time.Time
+}
+
+func NewIdempotencyStore(window time.Duration, maxBytes int64) *IdempotencyStore {
+	return &IdempotencyStore{window: window, maxBytes: maxBytes, entries: map[string]*idemEntry{}, order: list.New()}
+}
+
+func idempotencyKey(ctx context.Context, req interface{}) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(idempotencyHeader); len(vals) > 0 && vals[0] != "" {
+		return vals[0]
+	}
+	if r, ok := req.(interface{ GetIdempotencyKey() string }); ok {
+		return r.GetIdempotencyKey()
+	}
+	return ""
+}
+
+// payloadHash identifies the request body, ignoring the idempotency key itself.
+func payloadHash(method string, req interface{}) ([32]byte, error) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return sha256.Sum256([]byte(method)), nil
+	}
+	m = proto.Clone(m)
+	if f := m.ProtoReflect().Descriptor().Fields().ByName("idempotency_key"); f != nil {
+		m.ProtoReflect().Clear(f)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(append([]byte(method+"\x00"), b...)), nil
+}
+
+func (s *IdempotencyStore) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	key := idempotencyKey(ctx, req)
+	if key == "" {
+		return handler(ctx, req)
+	}
+	payload, err := payloadHash(info.FullMethod, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "hash request: %v", err)
+	}
+	scoped := CallerFromContext(ctx) + "\x00" + key
+
+	s.mu.Lock()
+	s.sweep()
+	if e, ok := s.entries[scoped]; ok && !e.expires.IsZero() && !time.Now().Before(e.expires) {
+		s.remove(e)
+	}
+	if e, ok := s.entries[scoped]; ok {
+		s.mu.Unlock()
+		if e.payload != payload {
+			return nil, status.Error(codes.AlreadyExists, "idempotency key reused with a different payload")
+		}
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		if e.err != nil {
+			// the first attempt failed and was forgotten; run this one for real
+			return s.UnaryInterceptor(ctx, req, info, handler)
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+		return e.resp, nil
+	}
+	e := &idemEntry{key: scoped, payload: payload, done: make(chan struct{})}
+	s.entries[scoped] = e
+	s.mu.Unlock()
+
+	resp, err := handler(ctx, req)
+
+	s.mu.Lock()
+	e.resp, e.err = resp, err
+	e.expires = time.Now().Add(s.window)
+	e.size = int64(len(scoped)) + idemEntryOverhead
+	if m, ok := resp.(proto.Message); ok {
+		e.size += int64(proto.Size(m))
+	}
+	switch {
+	case err != nil, s.maxBytes > 0 && e.size > s.maxBytes:
+		s.remove(e)
+	default:
+		e.elem = s.order.PushBack(e)
+		s.size += e.size
+		for s.maxBytes > 0 && s.size > s.maxBytes {
+			s.remove(s.order.Front().Value.(*idemEntry))
+		}
+	}
+	s.mu.Unlock()
+	close(e.done)
+	return resp, err
+}
+
+// sweep drops expired entries; must be called with s.mu held.
+func (s *IdempotencyStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	// order diurutkan menurut selesai, jadi yang kedaluwarsa ada di depan
+	for el := s.order.Front(); el != nil; el = s.order.Front() {
+		e := el.Value.(*idemEntry)
+		if now.Before(e.expires) {
+			break
+		}
+		s.remove(e)
+	}
+}
+
+// remove forgets e; must be called with s.mu held.
+func (s *IdempotencyStore) remove(e *idemEntry) {
+	if s.entries[e.key] == e {
+		delete(s.entries, e.key)
+	}
+	if e.elem != nil {
+		s.order.Remove(e.elem)
+		s.size -= e.size
+		e.elem = nil
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIdempotencyStoreEvictsOldest(t *testing.T) {
+	content := make([]byte, 1000)
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &docgenpb.GenerateResponse{Content: content}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GeneratePDF_FullMethodName}
+	ctx := withPrincipal(context.Background(), &Principal{ID: "key:1"})
+	// cukup untuk dua response
+	s := NewIdempotencyStore(time.Hour, 2*(1000+idemEntryOverhead+100))
+
+	call := func(key string) {
+		t.Helper()
+		if _, err := s.UnaryInterceptor(ctx, &docgenpb.GenerateRequest{IdempotencyKey: key}, info, handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, k := range []string{"a", "b", "b", "c"} {
+		call(k)
+	}
+	if calls != 3 {
+		t.Fatalf("handler ran %d times, want 3 (b replayed)", calls)
+	}
+	if len(s.entries) != 2 || s.entries["key:1\x00a"] != nil {
+		t.Fatalf("entries = %d, want b and c only", len(s.entries))
+	}
+	call("a")
+	if calls != 4 {
+		t.Fatalf("evicted key a was replayed")
+	}
+	if s.size > s.maxBytes {
+		t.Fatalf("size %d over cap %d", s.size, s.maxBytes)
+	}
+}
+
+func TestIdempotencyStoreSkipsOversized(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &docgenpb.GenerateResponse{Content: make([]byte, 4096)}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GeneratePDF_FullMethodName}
+	s := NewIdempotencyStore(time.Hour, 1024)
+	if _, err := s.UnaryInterceptor(context.Background(), &docgenpb.GenerateRequest{IdempotencyKey: "k"}, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.entries) != 0 || s.size != 0 {
+		t.Fatalf("oversized response was kept: %d entries, %d bytes", len(s.entries), s.size)
+	}
+}
+
+func TestIdempotencyStorePayloadMismatch(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &docgenpb.GenerateResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GeneratePDF_FullMethodName}
+	s := NewIdempotencyStore(time.Hour, 1<<20)
+	ctx := context.Background()
+	if _, err := s.UnaryInterceptor(ctx, &docgenpb.GenerateRequest{IdempotencyKey: "k", FilenameHint: "a"}, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.UnaryInterceptor(ctx, &docgenpb.GenerateRequest{IdempotencyKey: "k", FilenameHint: "b"}, info, handler)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("err = %v, want AlreadyExists", err)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DocxLimits bounds what an uploaded DOCX may expand to. Every DOCX that
+// reaches the service (templates, merge sources) is checked against them
+// before it is written to disk or opened by go-docx / LibreOffice.
+type DocxLimits struct {
+	MaxCompressedBytes   int64   // size of the upload
+	MaxUncompressedBytes int64   // sum of all entries, as actually decompressed
+	MaxEntries           int     // files in the zip
+	MaxCompressionRatio  float64 // per entry, for entries over 1 MiB
+	MaxXMLDepth          int     // element nesting in .xml/.rels parts
+}
+
+// DefaultDocxLimits fit real-world templates with room to spare.
+var DefaultDocxLimits = DocxLimits{
+	MaxCompressedBytes:   32 << 20,
+	MaxUncompressedBytes: 256 << 20,
+	MaxEntries:           2000,
+	MaxCompressionRatio:  100,
+	MaxXMLDepth:          256,
+}
+
+// ratioFloor: file kecil (mis. XML berulang) wajar punya rasio tinggi
+const ratioFloor = 1 << 20
+
+// WithDocxLimits replaces DefaultDocxLimits.
+func WithDocxLimits(l DocxLimits) Option {
+	return func(s *DocService) { s.limits = l }
+}
+
+// wordMainContentTypes are the content types accepted for word/document.xml.
+var wordMainContentTypes = map[string]bool{
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml": true,
+	"application/vnd.ms-word.document.macroEnabled.main+xml":                           true,
+	"application/vnd.ms-word.template.macroEnabledTemplate.main+xml":                   true,
+}
+
+// allowedContentTypePrefixes cover the parts Word writes: OOXML parts, images,
+// fonts, embedded Office objects. Anything else (HTML, scripts, executables)
+// has no business in a template.
+var allowedContentTypePrefixes = []string{
+	"application/vnd.openxmlformats-",
+	"application/vnd.ms-",
+	"application/xml",
+	"text/xml",
+	"image/",
+	"font/",
+	"application/x-font",
+	"application/font-",
+	"application/x-emf",
+	"application/x-wmf",
+	"application/postscript",
+	"application/octet-stream",
+}
+
+// checkDocx validates an uploaded DOCX; what names it in the error, e.g.
+// "template".
+func (s *DocService) checkDocx(what string, b []byte) error {
+	if err := s.limits.check(b); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", what, err)
+	}
+	return nil
+}
+
+func (l DocxLimits) check(b []byte) error {
+	if l.MaxCompressedBytes > 0 && int64(len(b)) > l.MaxCompressedBytes {
+		return fmt.Errorf("size %d bytes exceeds limit %d", len(b), l.MaxCompressedBytes)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return fmt.Errorf("not a DOCX (zip) file: %v", err)
+	}
+	if l.MaxEntries > 0 && len(zr.File) > l.MaxEntries {
+		return fmt.Errorf("%d zip entries exceed limit %d", len(zr.File), l.MaxEntries)
+	}
+
+	seen := map[string]bool{}
+	var contentTypes *zip.File
+	for _, f := range zr.File {
+		if err := checkEntryName(f.Name); err != nil {
+			return err
+		}
+		key := strings.ToLower(f.Name)
+		if seen[key] {
+			return fmt.Errorf("duplicate zip entry %q", f.Name)
+		}
+		seen[key] = true
+		if f.Name == contentTypesPart {
+			contentTypes = f
+		}
+	}
+	if contentTypes == nil {
+		return fmt.Errorf("%s is missing", contentTypesPart)
+	}
+	if !seen[mainDocumentPart] {
+		return fmt.Errorf("%s is missing", mainDocumentPart)
+	}
+
+	// ukuran di header zip bisa dipalsukan, jadi yang dihitung hasil dekompresi sebenarnya
+	remaining := l.MaxUncompressedBytes
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		n, err := l.inflate(f, remaining)
+		if err != nil {
+			return err
+		}
+		if l.MaxUncompressedBytes > 0 {
+			remaining -= n
+			if remaining < 0 {
+				return fmt.Errorf("uncompressed size exceeds limit %d bytes", l.MaxUncompressedBytes)
+			}
+		}
+		if l.MaxCompressionRatio > 0 && n > ratioFloor {
+			if ratio := float64(n) / float64(max(f.CompressedSize64, 1)); ratio > l.MaxCompressionRatio {
+				return fmt.Errorf("entry %q has compression ratio %.0f, limit %.0f", f.Name, ratio, l.MaxCompressionRatio)
+			}
+		}
+	}
+	return checkContentTypes(contentTypes)
+}
+
+// checkEntryName rejects names that could escape an extraction directory.
+func checkEntryName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("empty zip entry name")
+	case strings.ContainsAny(name, "\\\x00"):
+		return fmt.Errorf("invalid zip entry name %q", name)
+	case strings.HasPrefix(name, "/") || (len(name) > 1 && name[1] == ':'):
+		return fmt.Errorf("absolute zip entry name %q", name)
+	}
+	for _, seg := range strings.Split(name, "/") {
+		if seg == ".." {
+			return fmt.Errorf("path traversal in zip entry name %q", name)
+		}
+	}
+	if path.Clean(name) != strings.TrimSuffix(name, "/") {
+		return fmt.Errorf("non-canonical zip entry name %q", name)
+	}
+	return nil
+}
+
+// inflate decompresses f, reading at most budget+1 bytes (no cap when the
+// uncompressed limit is off), and checks XML nesting on the way.
+func (l DocxLimits) inflate(f *zip.File, budget int64) (int64, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, fmt.Errorf("entry %q: %v", f.Name, err)
+	}
+	defer rc.Close()
+	var r io.Reader = rc
+	if l.MaxUncompressedBytes > 0 {
+		r = io.LimitReader(rc, budget+1)
+	}
+	cr := &countingReader{r: r}
+
+	lower := strings.ToLower(f.Name)
+	if l.MaxXMLDepth > 0 && (strings.HasSuffix(lower, ".xml") || strings.HasSuffix(lower, ".rels")) {
+		d := xml.NewDecoder(cr)
+		depth := 0
+		for {
+			tok, err := d.RawToken()
+			if err != nil {
+				break // XML rusak bukan urusan intake; sisanya tetap dihitung di bawah
+			}
+			switch tok.(type) {
+			case xml.StartElement:
+				if depth++; depth > l.MaxXMLDepth {
+					return 0, fmt.Errorf("entry %q nests XML deeper than %d", f.Name, l.MaxXMLDepth)
+				}
+			case xml.EndElement:
+				depth--
+			}
+		}
+	}
+	if _, err := io.Copy(io.Discard, cr); err != nil {
+		return 0, fmt.Errorf("entry %q: %v", f.Name, err)
+	}
+	return cr.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func checkContentTypes(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%s: %v", contentTypesPart, err)
+	}
+	defer rc.Close()
+	ct := &contentTypes{}
+	if err := xml.NewDecoder(rc).Decode(ct); err != nil {
+		return fmt.Errorf("%s: %v", contentTypesPart, err)
+	}
+	for _, d := range ct.Defaults {
+		if !allowedContentType(d.ContentType) {
+			return fmt.Errorf("unexpected content type %q for .%s files", d.ContentType, d.Extension)
+		}
+	}
+	for _, o := range ct.Overrides {
+		if !allowedContentType(o.ContentType) {
+			return fmt.Errorf("unexpected content type %q for %s", o.ContentType, o.PartName)
+		}
+	}
+	if main := ct.contentTypeOf(mainDocumentPart); !wordMainContentTypes[main] {
+		return fmt.Errorf("%s has content type %q, not a Word document", mainDocumentPart, main)
+	}
+	return nil
+}
+
+func allowedContentType(t string) bool {
+	t = strings.ToLower(strings.TrimSpace(t))
+	for _, p := range allowedContentTypePrefixes {
+		if strings.HasPrefix(t, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JWTConfig describes the bearer tokens accepted besides API keys. HS256
+// needs HS256Secret; RS256 and ES256 need a JWKS from JWKSFile or JWKSURL
+// (e.g. the jwks_uri of an OIDC provider).
+type JWTConfig struct {
+	Issuer   string   // required "iss"
+	Audience []string // "aud" must contain one of them
+	Leeway   time.Duration
+
+	HS256Secret []byte
+	JWKSFile    string
+	JWKSURL     string
+	JWKSRefresh time.Duration // how long a fetched JWKS is used, default 1h
+
+	// claim names; default "sub", "scope", "tenant" and "name". The scopes
+	// claim may be a space-separated string (OAuth "scope") or an array.
+	SubjectClaim string
+	ScopesClaim  string
+	TenantClaim  string
+	NameClaim    string
+}
+
+// jwksMinRefetch limits refetches triggered by an unknown "kid".
+const jwksMinRefetch = time.Minute
+
+// JWTVerifier checks bearer tokens against a JWTConfig.
+type JWTVerifier struct {
+	cfg    JWTConfig
+	parser *jwt.Parser
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey // by kid
+	fetched time.Time                   // last load attempt
+	loaded  time.Time                   // last successful load
+}
+
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	if cfg.Issuer == "" || len(cfg.Audience) == 0 {
+		return nil, errors.New("jwt: issuer and audience are required")
+	}
+	if len(cfg.HS256Secret) == 0 && cfg.JWKSFile == "" && cfg.JWKSURL == "" {
+		return nil, errors.New("jwt: an HS256 secret or a JWKS is required")
+	}
+	if cfg.JWKSRefresh <= 0 {
+		cfg.JWKSRefresh = time.Hour
+	}
+	cfg.SubjectClaim = firstNonEmpty(cfg.SubjectClaim, "sub")
+	cfg.ScopesClaim = firstNonEmpty(cfg.ScopesClaim, "scope")
+	cfg.TenantClaim = firstNonEmpty(cfg.TenantClaim, "tenant")
+	cfg.NameClaim = firstNonEmpty(cfg.NameClaim, "name")
+	var methods []string
+	if len(cfg.HS256Secret) > 0 {
+		methods = append(methods, "HS256")
+	}
+	if cfg.JWKSFile != "" || cfg.JWKSURL != "" {
+		methods = append(methods, "RS256", "ES256")
+	}
+	v := &JWTVerifier{
+		cfg: cfg,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(methods),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience...),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.Leeway),
+		),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.JWKSFile != "" || cfg.JWKSURL != "" {
+		if err := v.loadJWKS(); err != nil {
+			// file salah = konfigurasi salah; URL bisa pulih sendiri
+			if cfg.JWKSURL == "" {
+				return nil, err
+			}
+			logger.Warn("jwks not loaded yet, will retry", zap.String("url", cfg.JWKSURL), zap.Error(err))
+		}
+	}
+	return v, nil
+}
+
+// Verify validates the token and maps its claims to a Principal.
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, status.Error(codes.Unauthenticated, "token expired")
+		case errors.Is(err, jwt.ErrTokenNotValidYet):
+			return nil, status.Error(codes.Unauthenticated, "token not valid yet")
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	sub, _ := claims[v.cfg.SubjectClaim].(string)
+	if sub == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: missing %q claim", v.cfg.SubjectClaim)
+	}
+	p := &Principal{ID: "jwt:" + sub, Method: "jwt", Name: sub}
+	if name, ok := claims[v.cfg.NameClaim].(string); ok && name != "" {
+		p.Name = name
+	}
+	p.Tenant, _ = claims[v.cfg.TenantClaim].(string)
+	switch sc := claims[v.cfg.ScopesClaim].(type) {
+	case string:
+		p.Scopes = strings.Fields(sc)
+	case []interface{}:
+		for _, s := range sc {
+			if s, ok := s.(string); ok {
+				p.Scopes = append(p.Scopes, s)
+			}
+		}
+	}
+	return p, nil
+}
+
+// key is the jwt.Keyfunc: the shared secret for HS256, the JWKS key named by
+// "kid" otherwise.
+func (v *JWTVerifier) key(t *jwt.Token) (interface{}, error) {
+	alg := t.Method.Alg()
+	if alg == "HS256" {
+		return v.cfg.HS256Secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	k, err := v.jwk(kid)
+	if err != nil {
+		return nil, err
+	}
+	switch pub := k.(type) {
+	case *rsa.PublicKey:
+		if alg == "RS256" {
+			return pub, nil
+		}
+	case *ecdsa.PublicKey:
+		if alg == "ES256" && pub.Curve == elliptic.P256() {
+			return pub, nil
+		}
+	}
+	return nil, fmt.Errorf("key %q cannot verify %s", kid, alg)
+}
+
+// jwk returns the key for kid, reloading the JWKS when it is stale or does
+// not know kid (key rotation at the provider).
+func (v *JWTVerifier) jwk(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := time.Now()
+	k, ok := v.lookup(kid)
+	stale := v.cfg.JWKSURL != "" && now.Sub(v.loaded) > v.cfg.JWKSRefresh
+	if (!ok || stale) && now.Sub(v.fetched) > jwksMinRefetch {
+		if err := v.loadJWKSLocked(); err != nil {
+			logger.Warn("reload jwks failed", zap.Error(err))
+		}
+		k, ok = v.lookup(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return k, nil
+}
+
+// lookup finds kid; a token without kid matches a JWKS with a single key.
+func (v *JWTVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, true
+		}
+	}
+	k, ok := v.keys[kid]
+	return k, ok
+}
+
+func (v *JWTVerifier) loadJWKS() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.loadJWKSLocked()
+}
+
+func (v *JWTVerifier) loadJWKSLocked() error {
+	v.fetched = time.Now()
+	var data []byte
+	var err error
+	if v.cfg.JWKSURL != "" {
+		data, err = v.fetchJWKS()
+	} else {
+		data, err = os.ReadFile(v.cfg.JWKSFile)
+	}
+	if err != nil {
+		return fmt.Errorf("jwks: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("jwks: %w", err)
+	}
+	v.keys, v.loaded = keys, v.fetched
+	return nil
+}
+
+func (v *JWTVerifier) fetchJWKS() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", v.cfg.JWKSURL, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS reads the RSA and EC signing keys of a JWK set; other keys are
+// skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := map[string]crypto.PublicKey{}
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var pub crypto.PublicKey
+		var err error
+		switch k.Kty {
+		case "RSA":
+			pub, err = k.rsa()
+		case "EC":
+			pub, err = k.ecdsa()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %d (%q): %v", i, k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA or EC signing keys")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) rsa() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("n: %v", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("e: %v", err)
+	}
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	if pub.N.BitLen() < 2048 || pub.E < 3 {
+		return nil, errors.New("weak RSA key")
+	}
+	return pub, nil
+}
+
+func (k jsonWebKey) ecdsa() (*ecdsa.PublicKey, error) {
+	curve := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}[k.Crv]
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %v", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %v", err)
+	}
+	pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if _, err := pub.ECDH(); err != nil {
+		return nil, fmt.Errorf("invalid point: %v", err)
+	}
+	return pub, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var hsSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// jwtClaims returns valid claims for the test issuer and audience.
+func jwtClaims(extra jwt.MapClaims) jwt.MapClaims {
+	c := jwt.MapClaims{
+		"iss": "https://id.example.com",
+		"aud": "docgen",
+		"sub": "svc-billing",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		if v == nil {
+			delete(c, k)
+			continue
+		}
+		c[k] = v
+	}
+	return c
+}
+
+func signJWT(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, c jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func rsaJWK(kid string, pub *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{Kty: "RSA", Kid: kid, Use: "sig", N: b64(pub.N.Bytes()), E: b64(big.NewInt(int64(pub.E)).Bytes())}
+}
+
+func ecJWK(kid string, pub *ecdsa.PublicKey) jsonWebKey {
+	return jsonWebKey{Kty: "EC", Kid: kid, Crv: "P-256", X: b64(pub.X.FillBytes(make([]byte, 32))), Y: b64(pub.Y.FillBytes(make([]byte, 32)))}
+}
+
+func jwks(t *testing.T, keys ...jsonWebKey) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestJWTVerifyHS256(t *testing.T) {
+	v, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, HS256Secret: hsSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{"valid", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(nil)), codes.OK},
+		{"wrong secret", signJWT(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret!!"), "", jwtClaims(nil)), codes.Unauthenticated},
+		{"expired", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})), codes.Unauthenticated},
+		{"no exp", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"exp": nil})), codes.Unauthenticated},
+		{"not yet valid", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()})), codes.Unauthenticated},
+		{"wrong issuer", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"iss": "https://evil.example.com"})), codes.Unauthenticated},
+		{"wrong audience", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"aud": "other"})), codes.Unauthenticated},
+		{"no subject", signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"sub": nil})), codes.Unauthenticated},
+		{"RS256 without jwks", signJWT(t, jwt.SigningMethodRS256, rsaKey, "", jwtClaims(nil)), codes.Unauthenticated},
+		{"alg none", signJWT(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", jwtClaims(nil)), codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+			if status.Code(err) != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTClaimsToPrincipal(t *testing.T) {
+	v, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, HS256Secret: hsSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := v.Verify(signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{
+		"scope": "generate:pdf convert:docx", "tenant": "acme", "name": "Billing",
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "jwt:svc-billing" || p.Method != "jwt" || p.Name != "Billing" || p.Tenant != "acme" {
+		t.Errorf("principal = %+v", p)
+	}
+	if !p.HasScope("generate:pdf") || !p.HasScope("convert:docx") || p.HasScope("admin") {
+		t.Errorf("scopes = %v", p.Scopes)
+	}
+
+	// nama claim bisa diubah; scopes sebagai array
+	v, err = NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, HS256Secret: hsSecret,
+		SubjectClaim: "client_id", ScopesClaim: "roles", TenantClaim: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err = v.Verify(signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{
+		"client_id": "portal", "roles": []string{"generate:*"}, "org": "beta",
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "jwt:portal" || p.Tenant != "beta" || !p.HasScope("generate:pdf") {
+		t.Errorf("principal = %+v", p)
+	}
+}
+
+func TestJWTVerifyJWKSFile(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, JWKSFile: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaModulus := rsaKey.PublicKey.N.Bytes()
+	tests := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{"RS256", signJWT(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", jwtClaims(nil)), codes.OK},
+		{"ES256", signJWT(t, jwt.SigningMethodES256, ecKey, "ec-1", jwtClaims(nil)), codes.OK},
+		{"unknown kid", signJWT(t, jwt.SigningMethodRS256, otherKey, "rsa-2", jwtClaims(nil)), codes.Unauthenticated},
+		{"wrong key for kid", signJWT(t, jwt.SigningMethodRS256, otherKey, "rsa-1", jwtClaims(nil)), codes.Unauthenticated},
+		{"alg does not match key", signJWT(t, jwt.SigningMethodES256, ecKey, "rsa-1", jwtClaims(nil)), codes.Unauthenticated},
+		{"no kid with several keys", signJWT(t, jwt.SigningMethodRS256, rsaKey, "", jwtClaims(nil)), codes.Unauthenticated},
+		// HS256 dengan public key sebagai secret tidak boleh diterima
+		{"HS256 with public key", signJWT(t, jwt.SigningMethodHS256, rsaModulus, "rsa-1", jwtClaims(nil)), codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+			if status.Code(err) != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := os.WriteFile(path, []byte(`{"keys":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, JWKSFile: path}); err == nil {
+		t.Error("accepted a JWKS file without keys")
+	}
+}
+
+func TestJWTVerifyJWKSURLRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body atomic.Value
+	body.Store(jwks(t, rsaJWK("2026-01", &oldKey.PublicKey)))
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write(body.Load().([]byte))
+	}))
+	defer srv.Close()
+
+	v, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, JWKSURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(signJWT(t, jwt.SigningMethodRS256, oldKey, "", jwtClaims(nil))); err != nil {
+		t.Fatalf("single key without kid: %v", err)
+	}
+
+	// provider rotates its key; an unknown kid is refetched at most once a minute
+	body.Store(jwks(t, rsaJWK("2026-01", &oldKey.PublicKey), rsaJWK("2026-07", &newKey.PublicKey)))
+	rotated := signJWT(t, jwt.SigningMethodRS256, newKey, "2026-07", jwtClaims(nil))
+	if _, err := v.Verify(rotated); err == nil {
+		t.Fatal("refetched the JWKS within jwksMinRefetch")
+	}
+	v.mu.Lock()
+	v.fetched = v.fetched.Add(-2 * jwksMinRefetch)
+	v.mu.Unlock()
+	if _, err := v.Verify(rotated); err != nil {
+		t.Fatalf("new kid after refetch: %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+}
+
+func TestAuthenticateBearerJWT(t *testing.T) {
+	v, err := NewJWTVerifier(JWTConfig{Issuer: "https://id.example.com", Audience: []string{"docgen"}, HS256Secret: hsSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetJWTVerifier(v)
+	t.Cleanup(func() { SetJWTVerifier(nil) })
+
+	token := signJWT(t, jwt.SigningMethodHS256, hsSecret, "", jwtClaims(jwt.MapClaims{"scope": "generate:pdf"}))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	p, err := authenticate(ctx)
+	if err != nil || p.ID != "jwt:svc-billing" {
+		t.Fatalf("authenticate = %+v, %v", p, err)
+	}
+	// token di x-api-key bukan JWT, dicari sebagai api key
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", token))
+	if _, err := authenticate(ctx); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("jwt in x-api-key: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScopeAdmin allows managing API keys through KeyAdmin.
+const ScopeAdmin = "admin"
+
+// KeyAdmin implements the KeyAdmin RPCs on top of a KeyStore.
+type KeyAdmin struct {
+	docgenpb.UnimplementedKeyAdminServer
+	store KeyStore
+}
+
+func NewKeyAdmin(store KeyStore) *KeyAdmin {
+	return &KeyAdmin{store: store}
+}
+
+func parseExpiry(s string, now time.Time) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at %q: want RFC 3339", s)
+	}
+	if !t.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at is in the past")
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+func (a *KeyAdmin) CreateAPIKey(ctx context.Context, req *docgenpb.CreateAPIKeyRequest) (*docgenpb.APIKeySecret, error) {
+	if err := requireScope(ctx, ScopeAdmin); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	now := time.Now().UTC()
+	expires, err := parseExpiry(req.GetExpiresAt(), now)
+	if err != nil {
+		return nil, err
+	}
+	// admin sebuah tenant hanya membuat key untuk tenant-nya sendiri
+	tenant := req.GetTenant()
+	if t := TenantFromContext(ctx); t != "" {
+		if tenant != "" && tenant != t {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot create keys for tenant %q", tenant)
+		}
+		tenant = t
+	}
+	var scopes []string
+	for _, sc := range req.GetScopes() {
+		if sc = strings.TrimSpace(sc); sc != "" {
+			scopes = append(scopes, sc)
+		}
+	}
+	if err := grantable(ctx, scopes); err != nil {
+		return nil, err
+	}
+	id, err := newAPIKeyID()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate key id: %v", err)
+	}
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate key: %v", err)
+	}
+	k := &APIKey{
+		ID:        id,
+		Name:      req.GetName(),
+		Owner:     req.GetOwner(),
+		Tenant:    tenant,
+		Hash:      HashAPIKey(secret),
+		Scopes:    scopes,
+		ExpiresAt: expires,
+		CreatedAt: now,
+	}
+	if err := a.store.Put(k); err != nil {
+		return nil, status.Errorf(codes.Internal, "store key: %v", err)
+	}
+	logger.Info("api key created", zap.String("id", k.ID), zap.String("name", k.Name),
+		zap.Strings("scopes", k.Scopes), zap.String("by", CallerFromContext(ctx)))
+	return &docgenpb.APIKeySecret{Key: apiKeyProto(k), Secret: secret}, nil
+}
+
+func (a *KeyAdmin) RotateAPIKey(ctx context.Context, req *docgenpb.RotateAPIKeyRequest) (*docgenpb.APIKeySecret, error) {
+	if err := requireScope(ctx, ScopeAdmin); err != nil {
+		return nil, err
+	}
+	k, err := a.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if k.Disabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "key %q is revoked", k.ID)
+	}
+	// secret baru sama saja dengan membuat key dengan scope yang sama
+	if err := grantable(ctx, k.Scopes); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if req.GetExpiresAt() != "" {
+		if k.ExpiresAt, err = parseExpiry(req.GetExpiresAt(), now); err != nil {
+			return nil, err
+		}
+	}
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate key: %v", err)
+	}
+	k.Hash = HashAPIKey(secret)
+	k.RotatedAt = &now
+	if err := a.store.Put(k); err != nil {
+		return nil, status.Errorf(codes.Internal, "store key: %v", err)
+	}
+	logger.Info("api key rotated", zap.String("id", k.ID), zap.String("by", CallerFromContext(ctx)))
+	return &docgenpb.APIKeySecret{Key: apiKeyProto(k), Secret: secret}, nil
+}
+
+func (a *KeyAdmin) RevokeAPIKey(ctx context.Context, req *docgenpb.RevokeAPIKeyRequest) (*docgenpb.APIKey, error) {
+	if err := requireScope(ctx, ScopeAdmin); err != nil {
+		return nil, err
+	}
+	k, err := a.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !k.Disabled {
+		k.Disabled = true
+		if err := a.store.Put(k); err != nil {
+			return nil, status.Errorf(codes.Internal, "store key: %v", err)
+		}
+		logger.Info("api key revoked", zap.String("id", k.ID), zap.String("by", CallerFromContext(ctx)))
+	}
+	return apiKeyProto(k), nil
+}
+
+func (a *KeyAdmin) ListAPIKeys(ctx context.Context, req *docgenpb.ListAPIKeysRequest) (*docgenpb.ListAPIKeysResponse, error) {
+	if err := requireScope(ctx, ScopeAdmin); err != nil {
+		return nil, err
+	}
+	tenant := req.GetTenant()
+	if t := TenantFromContext(ctx); t != "" {
+		tenant = t
+	}
+	resp := &docgenpb.ListAPIKeysResponse{}
+	for _, k := range a.store.List() {
+		if tenant == "" || k.Tenant == tenant {
+			resp.Keys = append(resp.Keys, apiKeyProto(k))
+		}
+	}
+	return resp, nil
+}
+
+// grantable checks that the caller holds every scope in scopes, so admins
+// cannot hand out more than they have; "*" only comes from a "*" holder.
+func grantable(ctx context.Context, scopes []string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "no caller")
+	}
+	for _, sc := range scopes {
+		if !p.HasScope(sc) {
+			return status.Errorf(codes.PermissionDenied, "cannot grant scope %q the caller does not have", sc)
+		}
+	}
+	return nil
+}
+
+// get returns key id; keys of other tenants are not found.
+func (a *KeyAdmin) get(ctx context.Context, id string) (*APIKey, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	k, ok := a.store.Get(id)
+	if !ok || !visibleTo(ctx, k.Tenant) {
+		return nil, status.Errorf(codes.NotFound, "no api key %q", id)
+	}
+	return k, nil
+}
+
+// apiKeyProto converts k without its hash.
+func apiKeyProto(k *APIKey) *docgenpb.APIKey {
+	p := &docgenpb.APIKey{
+		Id:        k.ID,
+		Name:      k.Name,
+		Owner:     k.Owner,
+		Tenant:    k.Tenant,
+		Scopes:    k.Scopes,
+		Disabled:  k.Disabled,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+	}
+	if k.ExpiresAt != nil {
+		p.ExpiresAt = k.ExpiresAt.Format(time.RFC3339)
+	}
+	if k.RotatedAt != nil {
+		p.RotatedAt = k.RotatedAt.Format(time.RFC3339)
+	}
+	return p
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// APIKey is a stored API key. Only the hash of the secret is kept.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	Owner     string     `json:"owner,omitempty"`
+	Tenant    string     `json:"tenant,omitempty"`
+	Hash      string     `json:"hash"` // "sha256:<hex>" of the secret
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+}
+
+// usable returns why k cannot be used now, or "".
+func (k *APIKey) usable(now time.Time) string {
+	switch {
+	case k.Disabled:
+		return "api key revoked"
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		return "api key expired"
+	}
+	return ""
+}
+
+// KeyStore holds API keys. FileKeyStore is the built-in implementation; a
+// database-backed store only has to provide these methods.
+type KeyStore interface {
+	// Lookup finds a key by the hash of its secret (see HashAPIKey).
+	Lookup(hash string) (*APIKey, bool)
+	Get(id string) (*APIKey, bool)
+	List() []*APIKey
+	// Put creates the key or replaces the one with the same ID.
+	Put(k *APIKey) error
+}
+
+// HashAPIKey returns the stored form of a secret: "sha256:<hex>". Secrets are
+// random and long, so a fast hash is enough.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// newAPIKeySecret returns a random secret like "dgk_<43 base64url chars>".
+func newAPIKeySecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "dgk_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func newAPIKeyID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ---------- file ----------
+
+// FileKeyStore keeps keys in a JSON file (an array of APIKey) and in memory.
+// Writes replace the file atomically; Reload and Watch pick up edits made by
+// hand or by another instance.
+type FileKeyStore struct {
+	path string
+
+	mu     sync.RWMutex
+	byID   map[string]*APIKey
+	byHash map[string]*APIKey
+	mod    time.Time // mtime of the file as last read or written
+	size   int64
+}
+
+// OpenFileKeyStore loads path; a missing file is an empty store and is
+// created on the first Put.
+func OpenFileKeyStore(path string) (*FileKeyStore, error) {
+	s := &FileKeyStore{path: path}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload reads the file again. On error the keys loaded before stay in use.
+func (s *FileKeyStore) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reload()
+}
+
+func (s *FileKeyStore) reload() error {
+	var keys []*APIKey
+	fi, err := os.Stat(s.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return fmt.Errorf("%s: %w", s.path, err)
+		}
+	}
+	byID := make(map[string]*APIKey, len(keys))
+	byHash := make(map[string]*APIKey, len(keys))
+	for i, k := range keys {
+		if k.ID == "" || !strings.HasPrefix(k.Hash, "sha256:") {
+			return fmt.Errorf("%s: key %d: id and a sha256: hash are required", s.path, i)
+		}
+		if byID[k.ID] != nil {
+			return fmt.Errorf("%s: duplicate key id %q", s.path, k.ID)
+		}
+		k.Hash = strings.ToLower(k.Hash)
+		byID[k.ID] = k
+		byHash[k.Hash] = k
+	}
+	s.byID, s.byHash = byID, byHash
+	if fi != nil {
+		s.mod, s.size = fi.ModTime(), fi.Size()
+	}
+	return nil
+}
+
+// Watch reloads the file whenever its modification time or size changes,
+// checking every interval until stop is closed.
+func (s *FileKeyStore) Watch(interval time.Duration, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+		fi, err := os.Stat(s.path)
+		if err != nil {
+			continue
+		}
+		s.mu.Lock()
+		if !fi.ModTime().Equal(s.mod) || fi.Size() != s.size {
+			if err := s.reload(); err != nil {
+				// dicatat sekali per perubahan file, bukan setiap interval
+				s.mod, s.size = fi.ModTime(), fi.Size()
+				logger.Error("reload api keys failed, keeping previous keys", zap.String("path", s.path), zap.Error(err))
+			} else {
+				logger.Info("api keys reloaded", zap.String("path", s.path), zap.Int("keys", len(s.byID)))
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *FileKeyStore) Lookup(hash string) (*APIKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	c := *k
+	return &c, true
+}
+
+func (s *FileKeyStore) Get(id string) (*APIKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.byID[id]
+	if !ok {
+		return nil, false
+	}
+	c := *k
+	return &c, true
+}
+
+// List returns copies of all keys, oldest first.
+func (s *FileKeyStore) List() []*APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sorted()
+}
+
+func (s *FileKeyStore) sorted() []*APIKey {
+	out := make([]*APIKey, 0, len(s.byID))
+	for _, k := range s.byID {
+		c := *k
+		out = append(out, &c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (s *FileKeyStore) Put(k *APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if other, ok := s.byHash[k.Hash]; ok && other.ID != k.ID {
+		return fmt.Errorf("hash already used by key %q", other.ID)
+	}
+	c := *k
+	old := s.byID[c.ID]
+	s.byID[c.ID] = &c
+	if err := s.write(); err != nil {
+		// memori harus tetap sama dengan isi file
+		if old != nil {
+			s.byID[c.ID] = old
+		} else {
+			delete(s.byID, c.ID)
+		}
+		return err
+	}
+	if old != nil {
+		delete(s.byHash, old.Hash)
+	}
+	s.byHash[c.Hash] = &c
+	return nil
+}
+
+// write replaces the file with the current keys (temp file + rename).
+func (s *FileKeyStore) write() error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(append(data, '\n'))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o600)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(s.path); err == nil {
+		s.mod, s.size = fi.ModTime(), fi.Size()
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFileKeyStoreLookupByHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	ks, err := OpenFileKeyStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := "dgk_test-secret"
+	if err := ks.Put(&APIKey{ID: "k1", Name: "ci", Hash: HashAPIKey(secret), Scopes: []string{"generate:pdf"}, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), secret) {
+		t.Fatal("key file contains the plaintext secret")
+	}
+
+	reopened, err := OpenFileKeyStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, s := range map[string]KeyStore{"written": ks, "reopened": reopened} {
+		if k, ok := s.Lookup(HashAPIKey(secret)); !ok || k.ID != "k1" {
+			t.Errorf("%s: Lookup(hash) = %v, %v", name, k, ok)
+		}
+		if _, ok := s.Lookup(HashAPIKey("dgk_other")); ok {
+			t.Errorf("%s: found a key for a wrong secret", name)
+		}
+		if _, ok := s.Lookup(secret); ok {
+			t.Errorf("%s: found a key by its plaintext secret", name)
+		}
+	}
+}
+
+// keyAdminFixture is a KeyAdmin on a fresh file store that the auth
+// functions also use.
+func keyAdminFixture(t *testing.T) *KeyAdmin {
+	t.Helper()
+	ks, err := OpenFileKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetKeyStore(ks)
+	t.Cleanup(func() { SetKeyStore(nil) })
+	return NewKeyAdmin(ks)
+}
+
+func adminCtx(tenant string, scopes ...string) context.Context {
+	return withPrincipal(context.Background(), &Principal{ID: "key:admin", Tenant: tenant, Scopes: scopes})
+}
+
+func TestKeyAdminRotateAndRevoke(t *testing.T) {
+	a := keyAdminFixture(t)
+	ctx := adminCtx("", "*")
+
+	created, err := a.CreateAPIKey(ctx, &docgenpb.CreateAPIKeyRequest{Name: "ci", Tenant: "acme", Scopes: []string{"generate:pdf"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := lookupAPIKey(created.Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "key:"+created.Key.Id || p.Tenant != "acme" || !p.HasScope("generate:pdf") || p.HasScope("admin") {
+		t.Fatalf("principal = %+v", p)
+	}
+
+	rotated, err := a.RotateAPIKey(ctx, &docgenpb.RotateAPIKeyRequest{Id: created.Key.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.Secret == created.Secret {
+		t.Fatal("rotate returned the old secret")
+	}
+	if _, err := lookupAPIKey(created.Secret); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("old secret after rotate: %v", err)
+	}
+	if _, err := lookupAPIKey(rotated.Secret); err != nil {
+		t.Errorf("new secret after rotate: %v", err)
+	}
+
+	revoked, err := a.RevokeAPIKey(ctx, &docgenpb.RevokeAPIKeyRequest{Id: created.Key.Id})
+	if err != nil || !revoked.Disabled {
+		t.Fatalf("revoke = %v, %v", revoked, err)
+	}
+	if _, err := lookupAPIKey(rotated.Secret); err == nil || !strings.Contains(err.Error(), "revoked") {
+		t.Errorf("secret after revoke: %v", err)
+	}
+	if _, err := a.RotateAPIKey(ctx, &docgenpb.RotateAPIKeyRequest{Id: created.Key.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("rotate revoked key: %v", err)
+	}
+}
+
+func TestKeyAdminExpiredKey(t *testing.T) {
+	a := keyAdminFixture(t)
+	created, err := a.CreateAPIKey(adminCtx("", "*"), &docgenpb.CreateAPIKeyRequest{Name: "tmp", ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, _ := a.store.Get(created.Key.Id)
+	past := time.Now().Add(-time.Minute)
+	k.ExpiresAt = &past
+	if err := a.store.Put(k); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lookupAPIKey(created.Secret); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expired key: %v", err)
+	}
+}
+
+func TestKeyAdminScopeEscalation(t *testing.T) {
+	a := keyAdminFixture(t)
+	tests := []struct {
+		name   string
+		caller []string
+		grant  []string
+		want   codes.Code
+	}{
+		{"admin grants wildcard", []string{"admin"}, []string{"*"}, codes.PermissionDenied},
+		{"admin grants scope it lacks", []string{"admin"}, []string{"generate:pdf"}, codes.PermissionDenied},
+		{"admin grants admin", []string{"admin"}, []string{"admin"}, codes.OK},
+		{"prefix holder grants narrower", []string{"admin", "generate:*"}, []string{"generate:pdf"}, codes.OK},
+		{"narrow holder grants prefix", []string{"admin", "generate:pdf"}, []string{"generate:*"}, codes.PermissionDenied},
+		{"wildcard grants wildcard", []string{"*"}, []string{"*"}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.CreateAPIKey(adminCtx("", tt.caller...), &docgenpb.CreateAPIKeyRequest{Name: "k", Scopes: tt.grant})
+			if status.Code(err) != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// rotate hands out a working secret, so it needs the key's scopes too
+	root, err := a.CreateAPIKey(adminCtx("", "*"), &docgenpb.CreateAPIKeyRequest{Name: "root", Scopes: []string{"*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.RotateAPIKey(adminCtx("", "admin"), &docgenpb.RotateAPIKeyRequest{Id: root.Key.Id}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("admin rotated a * key: %v", err)
+	}
+}
+
+func TestKeyAdminTenantIsolation(t *testing.T) {
+	a := keyAdminFixture(t)
+	global := adminCtx("", "*")
+	acme := adminCtx("acme", "*")
+
+	other, err := a.CreateAPIKey(global, &docgenpb.CreateAPIKeyRequest{Name: "beta", Tenant: "beta"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	own, err := a.CreateAPIKey(acme, &docgenpb.CreateAPIKeyRequest{Name: "mine"})
+	if err != nil || own.Key.Tenant != "acme" {
+		t.Fatalf("create in own tenant = %v, %v", own, err)
+	}
+	if _, err := a.CreateAPIKey(acme, &docgenpb.CreateAPIKeyRequest{Name: "x", Tenant: "beta"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("create in other tenant: %v", err)
+	}
+	if _, err := a.RevokeAPIKey(acme, &docgenpb.RevokeAPIKeyRequest{Id: other.Key.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("revoke other tenant's key: %v", err)
+	}
+	list, err := a.ListAPIKeys(acme, &docgenpb.ListAPIKeysRequest{})
+	if err != nil || len(list.Keys) != 1 || list.Keys[0].Id != own.Key.Id {
+		t.Errorf("list = %v, %v", list, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -17,6 +18,26 @@ func InitLogger() {
 	logger = l
 }
 
+// ConfigureLogger replaces the logger; level is debug, info, warn or error,
+// format json or console.
+func ConfigureLogger(level, format string) error {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	cfg := zap.NewProductionConfig()
+	if format == "console" {
+		cfg = zap.NewDevelopmentConfig()
+	}
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+	l, err := cfg.Build()
+	if err != nil {
+		return err
+	}
+	logger = l
+	return nil
+}
+
 // Unary logging interceptor
 func UnaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	start := time.Now()
@@ -33,6 +54,7 @@ func UnaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.Un
 	logger.Info("grpc request",
 		zap.String("method", info.FullMethod),
 		zap.String("client", addr),
+		zap.String("caller", CallerFromContext(ctx)),
 		zap.Int("code", int(st)),
 		zap.Duration("duration", duration),
 	)
@@ -56,6 +78,7 @@ func StreamLoggingInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.
 		zap.Bool("isServerStream", info.IsServerStream),
 		zap.Bool("isClientStream", info.IsClientStream),
 		zap.String("client", addr),
+		zap.String("caller", CallerFromContext(ss.Context())),
 		zap.Int("code", int(st)),
 		zap.Duration("duration", duration),
 	)
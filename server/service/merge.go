@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *DocService) MergeDocuments(ctx context.Context, req *docgenpb.MergeRequest) (*docgenpb.GenerateResponse, error) {
+	if len(req.GetSources()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "sources is empty")
+	}
+	var resp *docgenpb.GenerateResponse
+	var sanitized []*docgenpb.SanitizedItem
+	var err error
+	switch req.GetOutputFormat() {
+	case docgenpb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF:
+		resp, sanitized, err = s.mergeToPDF(ctx, req)
+	case docgenpb.OutputFormat_OUTPUT_FORMAT_DOCX:
+		resp, sanitized, err = s.mergeToDocx(ctx, req)
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "merge to %v is not supported", req.GetOutputFormat())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, resp, ""); err != nil {
+		return nil, err
+	}
+	resp.Sanitized = sanitized
+	return resp, nil
+}
+
+func (s *DocService) mergeToPDF(ctx context.Context, req *docgenpb.MergeRequest) (*docgenpb.GenerateResponse, []*docgenpb.SanitizedItem, error) {
+	// 1) setiap bagian jadi PDF dulu
+	parts := make([][]byte, len(req.GetSources()))
+	titles := make([]string, len(req.GetSources()))
+	var sanitized []*docgenpb.SanitizedItem
+	for i, src := range req.GetSources() {
+		pdf, items, err := s.sourcePDF(ctx, src)
+		if err != nil {
+			return nil, nil, withSourceIndex(i, err)
+		}
+		sanitized = append(sanitized, withSourceLabel(i, items)...)
+		parts[i] = pdf
+		titles[i] = src.GetTitle()
+		if titles[i] == "" {
+			titles[i] = fmt.Sprintf("Bagian %d", i+1)
+		}
+	}
+
+	// 2) gabungkan
+	job := func() (*docgenpb.GenerateResponse, error) {
+		merged, err := mergePDFs(parts, titles, req.GetBookmarks(), req.GetNumberPages())
+		if err != nil {
+			return nil, err
+		}
+		return &docgenpb.GenerateResponse{
+			Content:     merged,
+			ContentType: "application/pdf",
+			Filename:    outputFilename(req.GetFilenameHint(), "pdf"),
+		}, nil
+	}
+	resp, err := s.wp.SubmitJob(ctx, job)
+	return resp, sanitized, err
+}
+
+func (s *DocService) mergeToDocx(ctx context.Context, req *docgenpb.MergeRequest) (*docgenpb.GenerateResponse, []*docgenpb.SanitizedItem, error) {
+	if req.GetBookmarks() || req.GetNumberPages() {
+		return nil, nil, status.Error(codes.Unimplemented, "bookmarks and number_pages are only supported for pdf output")
+	}
+	parts := make([][]byte, len(req.GetSources()))
+	var sanitized []*docgenpb.SanitizedItem
+	for i, src := range req.GetSources() {
+		docx, items, err := s.sourceDocx(ctx, src)
+		if err != nil {
+			return nil, nil, withSourceIndex(i, err)
+		}
+		sanitized = append(sanitized, withSourceLabel(i, items)...)
+		parts[i] = docx
+	}
+
+	job := func() (*docgenpb.GenerateResponse, error) {
+		merged, err := mergeDocx(parts)
+		if err != nil {
+			return nil, err
+		}
+		return &docgenpb.GenerateResponse{
+			Content:     merged,
+			ContentType: docxContentType,
+			Filename:    outputFilename(req.GetFilenameHint(), "docx"),
+		}, nil
+	}
+	resp, err := s.wp.SubmitJob(ctx, job)
+	return resp, sanitized, err
+}
+
+// sourceDocx renders one merge source to DOCX.
+func (s *DocService) sourceDocx(ctx context.Context, src *docgenpb.MergeSource) ([]byte, []*docgenpb.SanitizedItem, error) {
+	switch v := src.GetSource().(type) {
+	case *docgenpb.MergeSource_Generate:
+		resp, err := s.GenerateDocx(ctx, v.Generate)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.GetContent(), resp.GetSanitized(), nil
+	case *docgenpb.MergeSource_Docx:
+		if len(v.Docx) == 0 {
+			return nil, nil, status.Error(codes.InvalidArgument, "docx is empty")
+		}
+		if err := s.checkDocx("docx", v.Docx); err != nil {
+			return nil, nil, err
+		}
+		return s.sanitizeInput("docx", v.Docx)
+	case *docgenpb.MergeSource_Pdf:
+		return nil, nil, status.Error(codes.InvalidArgument, "pdf sources cannot be merged into docx")
+	default:
+		return nil, nil, status.Error(codes.InvalidArgument, "source is empty")
+	}
+}
+
+// sourcePDF renders or converts one merge source to PDF.
+func (s *DocService) sourcePDF(ctx context.Context, src *docgenpb.MergeSource) ([]byte, []*docgenpb.SanitizedItem, error) {
+	switch v := src.GetSource().(type) {
+	case *docgenpb.MergeSource_Generate:
+		if v.Generate.GetSecurity() != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "encrypted parts cannot be merged; encrypt the merged result instead")
+		}
+		if v.Generate.GetVerification() != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "verification stamps are per document; merged parts cannot carry one")
+		}
+		if v.Generate.GetSignature() != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "signed parts cannot be merged; merging invalidates the signature")
+		}
+		resp, err := s.GeneratePDF(ctx, v.Generate)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.GetContent(), resp.GetSanitized(), nil
+	case *docgenpb.MergeSource_Pdf:
+		if !bytes.HasPrefix(v.Pdf, []byte("%PDF-")) {
+			return nil, nil, status.Error(codes.InvalidArgument, "not a PDF")
+		}
+		return v.Pdf, nil, nil
+	case *docgenpb.MergeSource_Docx:
+		return s.convertDocxBytes(ctx, v.Docx)
+	default:
+		return nil, nil, status.Error(codes.InvalidArgument, "source is empty")
+	}
+}
+
+// convertDocxBytes converts a ready DOCX to PDF on the worker pool.
+func (s *DocService) convertDocxBytes(ctx context.Context, docxBytes []byte) ([]byte, []*docgenpb.SanitizedItem, error) {
+	if len(docxBytes) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "docx is empty")
+	}
+	if err := s.checkDocx("docx", docxBytes); err != nil {
+		return nil, nil, err
+	}
+	docxBytes, sanitized, err := s.sanitizeInput("docx", docxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmp, err := writeTemp("merge", ".docx", docxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.Remove(tmp)
+
+	resp, err := s.wp.SubmitJob(ctx, func() (*docgenpb.GenerateResponse, error) {
+		pdf, err := convertDocxToPDF(tmp, tenantFontDirs(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return &docgenpb.GenerateResponse{Content: pdf}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetContent(), sanitized, nil
+}
+
+func withSourceIndex(i int, err error) error {
+	st := status.Convert(err)
+	return status.Errorf(st.Code(), "source %d: %s", i, st.Message())
+}
+
+// withSourceLabel marks sanitizer items with the merge source they came from.
+func withSourceLabel(i int, items []*docgenpb.SanitizedItem) []*docgenpb.SanitizedItem {
+	for _, it := range items {
+		it.Source = fmt.Sprintf("source %d %s", i, it.GetSource())
+	}
+	return items
+}
+
+// mergePDFs concatenates parts in order, optionally adding one bookmark per
+// part and a continuous "n / total" page number at the bottom of every page.
+func mergePDFs(parts [][]byte, titles []string, bookmarks, numberPages bool) ([]byte, error) {
+	rs := make([]io.ReadSeeker, len(parts))
+	for i, p := range parts {
+		rs[i] = bytes.NewReader(p)
+	}
+	var out bytes.Buffer
+	if err := api.MergeRaw(rs, &out, false, nil); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "merge pdf: %v", err)
+	}
+	merged := out.Bytes()
+
+	if bookmarks {
+		bms := make([]pdfcpu.Bookmark, len(parts))
+		page := 1
+		for i, p := range parts {
+			n, err := api.PageCount(bytes.NewReader(p), nil)
+			if err != nil {
+				return nil, withSourceIndex(i, status.Errorf(codes.InvalidArgument, "page count: %v", err))
+			}
+			bms[i] = pdfcpu.Bookmark{Title: titles[i], PageFrom: page}
+			page += n
+		}
+		var w bytes.Buffer
+		if err := api.AddBookmarks(bytes.NewReader(merged), &w, bms, true, nil); err != nil {
+			return nil, status.Errorf(codes.Internal, "add bookmarks: %v", err)
+		}
+		merged = w.Bytes()
+	}
+
+	if numberPages {
+		// %p / %P are expanded per page by pdfcpu
+		wm, err := api.TextWatermark("%p / %P", "fontname:Helvetica, points:9, scalefactor:1 abs, rotation:0, position:bc, offset:0 18, fillcolor:#000000, opacity:1", true, false, types.POINTS)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "page numbers: %v", err)
+		}
+		var w bytes.Buffer
+		if err := api.AddWatermarks(bytes.NewReader(merged), &w, nil, wm, nil); err != nil {
+			return nil, status.Errorf(codes.Internal, "page numbers: %v", err)
+		}
+		merged = w.Bytes()
+	}
+	return merged, nil
+}
@@ -1,13 +1,53 @@
 package service
 
 import (
+	"context"
 	"go.uber.org/zap"
 	"net/http"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "docgen_cache_requests_total",
+	Help: "Result cache lookups for generate calls, by tenant and result (hit, miss, bypass).",
+}, []string{"tenant", "result"})
+
+var limitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "docgen_limited_requests_total",
+	Help: "Calls rejected by the per-client limiter, by tenant and reason (rate, daily_quota, monthly_quota).",
+}, []string{"tenant", "reason"})
+
+// tenantRequests counts calls per tenant; the grpc_prometheus metrics do not
+// know about tenants. Global callers have tenant "".
+var tenantRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "docgen_tenant_requests_total",
+	Help: "Handled calls by tenant, method and status code.",
+}, []string{"tenant", "method", "code"})
+
+func init() {
+	prometheus.MustRegister(cacheRequests, limitedRequests, tenantRequests)
+}
+
+// TenantMetricsUnaryInterceptor counts calls in docgen_tenant_requests_total.
+// Place it after the auth interceptor.
+func TenantMetricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	tenantRequests.WithLabelValues(TenantFromContext(ctx), info.FullMethod, status.Code(err).String()).Inc()
+	return resp, err
+}
+
+// TenantMetricsStreamInterceptor is TenantMetricsUnaryInterceptor for streams.
+func TenantMetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	tenantRequests.WithLabelValues(TenantFromContext(ss.Context()), info.FullMethod, status.Code(err).String()).Inc()
+	return err
+}
+
 // RegisterMetrics initializes grpc_prometheus and starts HTTP /metrics server
 func RegisterMetrics(listenAddr string) {
 	// enable histograms (optional)
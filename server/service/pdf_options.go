@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type filterValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// pdfExportFilter builds the --convert-to target for PDF output, passing opts
+// as writer_pdf_Export filter options (JSON syntax, LibreOffice >= 7.4).
+func pdfExportFilter(opts *docgenpb.PdfOptions) (string, error) {
+	if opts == nil {
+		return "pdf", nil
+	}
+	fo := map[string]filterValue{}
+	setBool := func(name string, v *bool) {
+		if v != nil {
+			fo[name] = filterValue{Type: "boolean", Value: strconv.FormatBool(*v)}
+		}
+	}
+	setLong := func(name string, v int32) {
+		fo[name] = filterValue{Type: "long", Value: strconv.Itoa(int(v))}
+	}
+
+	switch opts.GetPdfa() {
+	case docgenpb.PdfALevel_PDFA_NONE:
+	case docgenpb.PdfALevel_PDFA_1B, docgenpb.PdfALevel_PDFA_2B, docgenpb.PdfALevel_PDFA_3B:
+		// SelectPdfVersion: 1 = PDF/A-1b, 2 = PDF/A-2b, 3 = PDF/A-3b
+		setLong("SelectPdfVersion", int32(opts.GetPdfa()))
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown pdfa level %v", opts.GetPdfa())
+	}
+	setBool("UseTaggedPDF", opts.Tagged)
+	setBool("EmbedStandardFonts", opts.EmbedStandardFonts)
+	setBool("UseLosslessCompression", opts.LosslessImages)
+	setBool("ExportBookmarks", opts.ExportBookmarks)
+	setBool("ExportFormFields", opts.ExportFormFields)
+	if q := opts.GetJpegQuality(); q != 0 {
+		if q < 1 || q > 100 {
+			return "", status.Errorf(codes.InvalidArgument, "jpeg_quality must be 1-100, got %d", q)
+		}
+		setLong("Quality", q)
+	}
+	if dpi := opts.GetMaxImageDpi(); dpi != 0 {
+		if dpi < 1 || dpi > 2400 {
+			return "", status.Errorf(codes.InvalidArgument, "max_image_dpi must be 1-2400, got %d", dpi)
+		}
+		fo["ReduceImageResolution"] = filterValue{Type: "boolean", Value: "true"}
+		setLong("MaxImageResolution", dpi)
+	}
+	if len(fo) == 0 {
+		return "pdf", nil
+	}
+	b, err := json.Marshal(fo)
+	if err != nil {
+		return "", err
+	}
+	return "pdf:writer_pdf_Export:" + string(b), nil
+}
+
+// XMP identification schema, element (<pdfaid:part>2</...>) or attribute (pdfaid:part="2") form.
+var (
+	rePDFAPart        = regexp.MustCompile(`pdfaid:part(?:>|\s*=\s*["'])\s*(\d)`)
+	rePDFAConformance = regexp.MustCompile(`pdfaid:conformance(?:>|\s*=\s*["'])\s*([ABUabu])`)
+)
+
+// verifyPDFA checks the XMP metadata of pdf declares the requested PDF/A level.
+// This only confirms the identification LibreOffice writes; it is not a full
+// conformance check (use veraPDF for that).
+func verifyPDFA(pdf []byte, level docgenpb.PdfALevel) error {
+	want := map[docgenpb.PdfALevel]string{
+		docgenpb.PdfALevel_PDFA_1B: "1",
+		docgenpb.PdfALevel_PDFA_2B: "2",
+		docgenpb.PdfALevel_PDFA_3B: "3",
+	}[level]
+	if want == "" {
+		return nil
+	}
+	part := rePDFAPart.FindSubmatch(pdf)
+	conf := rePDFAConformance.FindSubmatch(pdf)
+	if part == nil || conf == nil {
+		return status.Errorf(codes.Internal, "converted PDF has no PDF/A identification metadata (requested %v)", level)
+	}
+	if string(part[1]) != want || (string(conf[1]) != "B" && string(conf[1]) != "b") {
+		return status.Errorf(codes.Internal, "converted PDF declares PDF/A-%s%s, requested %v", part[1], conf[1], level)
+	}
+	return nil
+}
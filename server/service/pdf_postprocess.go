@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func init() {
+	// pdfcpu would otherwise create ~/.config/pdfcpu on first use
+	api.DisableConfigDir()
+}
+
+// postProcessPDF runs the post-conversion stages on a PDF produced by render.
+// Stages run in order; each takes and returns the whole document.
+func postProcessPDF(pdf []byte, req *docgenpb.GenerateRequest) ([]byte, error) {
+	// 1) PDF/A identification check, before anything else touches the file
+	if err := verifyPDFA(pdf, req.GetPdf().GetPdfa()); err != nil {
+		return nil, err
+	}
+	// 2) watermark
+	if wm := req.GetWatermark(); wm != nil {
+		var err error
+		if pdf, err = watermarkPDF(pdf, wm); err != nil {
+			return nil, err
+		}
+	}
+	// last) encryption, nothing can modify the document after this
+	if sec := req.GetSecurity(); sec != nil {
+		return encryptPDF(pdf, sec)
+	}
+	return pdf, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pdfPermissions maps the request flags to the PDF permission bits (P entry).
+func pdfPermissions(sec *docgenpb.PdfSecurity) model.PermissionFlags {
+	p := model.PermissionsAll
+	if sec.GetNoPrint() {
+		p &^= model.PermissionPrintRev2 | model.PermissionPrintRev3
+	}
+	if sec.GetNoCopy() {
+		p &^= model.PermissionExtract | model.PermissionExtractRev3
+	}
+	if sec.GetNoModify() {
+		p &^= model.PermissionModify | model.PermissionModAnnFillForm | model.PermissionFillRev3 | model.PermissionAssembleRev3
+	}
+	return p
+}
+
+// encryptPDF encrypts pdf with AES-256 and applies the permission flags in sec.
+// Without an owner password a random one is used, so the restrictions cannot be
+// lifted by whoever knows the user password.
+func encryptPDF(pdf []byte, sec *docgenpb.PdfSecurity) ([]byte, error) {
+	owner := sec.GetOwnerPassword()
+	if owner == "" {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		owner = base64.RawURLEncoding.EncodeToString(b)
+	}
+	if owner == sec.GetUserPassword() {
+		return nil, status.Error(codes.InvalidArgument, "owner_password must differ from user_password")
+	}
+
+	conf := model.NewAESConfiguration(sec.GetUserPassword(), owner, 256)
+	conf.Permissions = pdfPermissions(sec)
+
+	var out bytes.Buffer
+	if err := api.Encrypt(bytes.NewReader(pdf), &out, conf); err != nil {
+		return nil, status.Errorf(codes.Internal, "encrypt pdf: %v", err)
+	}
+	return out.Bytes(), nil
+}
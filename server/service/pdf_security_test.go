@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:embed testdata/sample.pdf
+var samplePDF []byte
+
+func TestEncryptPDF(t *testing.T) {
+	printBits := model.PermissionPrintRev2 | model.PermissionPrintRev3
+	copyBits := model.PermissionExtract | model.PermissionExtractRev3
+	modifyBits := model.PermissionModify | model.PermissionModAnnFillForm | model.PermissionFillRev3 | model.PermissionAssembleRev3
+
+	tests := []struct {
+		name     string
+		sec      *docgenpb.PdfSecurity
+		denied   model.PermissionFlags
+		allowed  model.PermissionFlags
+		wantCode codes.Code
+	}{
+		{name: "user password only", sec: &docgenpb.PdfSecurity{UserPassword: "3171234567890001"},
+			allowed: printBits | copyBits | modifyBits},
+		{name: "no print", sec: &docgenpb.PdfSecurity{UserPassword: "u", NoPrint: true},
+			denied: printBits, allowed: copyBits | modifyBits},
+		{name: "no copy", sec: &docgenpb.PdfSecurity{UserPassword: "u", NoCopy: true},
+			denied: copyBits, allowed: printBits | modifyBits},
+		{name: "no modify", sec: &docgenpb.PdfSecurity{UserPassword: "u", NoModify: true},
+			denied: modifyBits, allowed: printBits | copyBits},
+		{name: "everything restricted, no user password", sec: &docgenpb.PdfSecurity{OwnerPassword: "owner", NoPrint: true, NoCopy: true, NoModify: true},
+			denied: printBits | copyBits | modifyBits},
+		{name: "owner equals user", sec: &docgenpb.PdfSecurity{UserPassword: "same", OwnerPassword: "same"},
+			wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := encryptPDF(samplePDF, tt.sec)
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("err = %v, want %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(out, []byte("Surat Keterangan")) {
+				t.Error("content stream is still readable")
+			}
+
+			conf := model.NewDefaultConfiguration()
+			conf.UserPW = tt.sec.GetUserPassword()
+			p, err := api.GetPermissions(bytes.NewReader(out), conf)
+			if err != nil {
+				t.Fatalf("open with user password: %v", err)
+			}
+			if p == nil {
+				t.Fatal("output is not encrypted")
+			}
+			got := model.PermissionFlags(uint16(*p))
+			if got&tt.denied != 0 {
+				t.Errorf("permissions %012b grant denied bits %012b", got, got&tt.denied)
+			}
+			if got&tt.allowed != tt.allowed {
+				t.Errorf("permissions %012b miss allowed bits %012b", got, tt.allowed&^got)
+			}
+
+			if tt.sec.GetUserPassword() != "" {
+				wrong := model.NewDefaultConfiguration()
+				wrong.UserPW = "wrong"
+				if _, err := api.GetPermissions(bytes.NewReader(out), wrong); err == nil {
+					t.Error("opened with a wrong password")
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptPDFOwnerPassword(t *testing.T) {
+	lift := func(pdf []byte, owner string) error {
+		conf := model.NewDefaultConfiguration()
+		conf.UserPW, conf.OwnerPW = "u", owner
+		conf.Permissions = model.PermissionsAll
+		return api.SetPermissions(bytes.NewReader(pdf), &bytes.Buffer{}, conf)
+	}
+	known, err := encryptPDF(samplePDF, &docgenpb.PdfSecurity{UserPassword: "u", OwnerPassword: "o", NoModify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lift(known, "o"); err != nil {
+		t.Fatalf("owner password cannot change permissions: %v", err)
+	}
+	// tanpa owner password, password user tidak boleh bisa mengangkat pembatasan
+	random, err := encryptPDF(samplePDF, &docgenpb.PdfSecurity{UserPassword: "u", NoModify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lift(random, "u"); err == nil {
+		t.Error("user password could change the permissions")
+	}
+}
+
+func TestPostProcessPDFEncryptsLast(t *testing.T) {
+	req := &docgenpb.GenerateRequest{
+		Watermark: &docgenpb.Watermark{Text: "DRAFT"},
+		Security:  &docgenpb.PdfSecurity{UserPassword: "u", OwnerPassword: "o"},
+	}
+	out, err := postProcessPDF(samplePDF, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = "u"
+	if p, err := api.GetPermissions(bytes.NewReader(out), conf); err != nil || p == nil {
+		t.Fatalf("result is not an encrypted PDF: %v", err)
+	}
+}
@@ -0,0 +1,576 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// defaultSignerProfile is used when a request does not name a profile.
+const defaultSignerProfile = "default"
+
+// SignerConfig describes one signing profile: a certificate + private key
+// from a PKCS#12 file or PEM files, and optional defaults for the signature.
+type SignerConfig struct {
+	Name        string `json:"name"`
+	PKCS12File  string `json:"pkcs12_file"`
+	CertFile    string `json:"cert_file"`    // PEM; boleh berisi chain setelah sertifikat signer
+	KeyFile     string `json:"key_file"`     // PEM, PKCS#1 / PKCS#8 / SEC1, tidak terenkripsi
+	PasswordEnv string `json:"password_env"` // env var berisi password PKCS#12
+	TSAURL      string `json:"tsa_url"`      // RFC 3161; "local" = TSA in-process untuk dev
+	Reason      string `json:"reason"`
+	Location    string `json:"location"`
+	ContactInfo string `json:"contact_info"`
+	Tenant      string `json:"tenant"` // kosong = boleh dipakai semua tenant
+}
+
+// Signer is a loaded signing profile.
+type Signer struct {
+	name  string
+	cert  *x509.Certificate
+	chain []*x509.Certificate
+	key   crypto.Signer
+	tsa   Timestamper
+	cfg   SignerConfig
+}
+
+// NewSigner loads the certificate and key described by c.
+func NewSigner(c SignerConfig) (*Signer, error) {
+	if c.Name == "" {
+		c.Name = defaultSignerProfile
+	}
+	s := &Signer{name: c.Name, cfg: c}
+	var key interface{}
+	switch {
+	case c.PKCS12File != "":
+		pfx, err := os.ReadFile(c.PKCS12File)
+		if err != nil {
+			return nil, err
+		}
+		k, cert, chain, err := pkcs12.DecodeChain(pfx, os.Getenv(c.PasswordEnv))
+		if err != nil {
+			return nil, fmt.Errorf("signer %s: %w", c.Name, err)
+		}
+		key, s.cert, s.chain = k, cert, chain
+	case c.CertFile != "" && c.KeyFile != "":
+		certs, err := readPEMCertificates(c.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("signer %s: %w", c.Name, err)
+		}
+		s.cert, s.chain = certs[0], certs[1:]
+		if key, err = readPEMKey(c.KeyFile); err != nil {
+			return nil, fmt.Errorf("signer %s: %w", c.Name, err)
+		}
+	default:
+		return nil, fmt.Errorf("signer %s: pkcs12_file or cert_file + key_file is required", c.Name)
+	}
+	k, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signer %s: unsupported key type %T", c.Name, key)
+	}
+	s.key = k
+
+	switch c.TSAURL {
+	case "":
+	case "local":
+		s.tsa = &LocalTimestamper{Cert: s.cert, Key: s.key}
+	default:
+		s.tsa = &HTTPTimestamper{URL: c.TSAURL}
+	}
+	return s, nil
+}
+
+// LoadSigners reads a JSON array of SignerConfig and loads every profile.
+func LoadSigners(path string) (map[string]*Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfgs []SignerConfig
+	if err := json.Unmarshal(b, &cfgs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	signers := map[string]*Signer{}
+	for _, c := range cfgs {
+		s, err := NewSigner(c)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := signers[s.name]; dup {
+			return nil, fmt.Errorf("%s: duplicate signer profile %q", path, s.name)
+		}
+		signers[s.name] = s
+	}
+	return signers, nil
+}
+
+// WithSigners enables the signature option of generate requests with the
+// given profiles, keyed by name.
+func WithSigners(signers map[string]*Signer) Option {
+	return func(s *DocService) { s.signers = signers }
+}
+
+// signer returns the profile a request asks for.
+func (s *DocService) signer(ctx context.Context, sig *docgenpb.PdfSignature) (*Signer, error) {
+	if len(s.signers) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "pdf signing is not configured on this server")
+	}
+	name := signerProfile(ctx, sig)
+	sg, ok := s.signers[name]
+	// profile tenant lain diperlakukan seperti tidak ada
+	if !ok || (sg.cfg.Tenant != "" && !visibleTo(ctx, sg.cfg.Tenant)) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown signer profile %q", name)
+	}
+	if sig.GetTimestamp() && sg.tsa == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "signer profile %q has no tsa_url", name)
+	}
+	return sg, nil
+}
+
+// signerProfile is the profile sig asks for, else the default of the caller's
+// tenant, else defaultSignerProfile.
+func signerProfile(ctx context.Context, sig *docgenpb.PdfSignature) string {
+	if name := sig.GetProfile(); name != "" {
+		return name
+	}
+	if t, ok := tenantSettings(TenantFromContext(ctx)); ok && t.Signer != "" {
+		return t.Signer
+	}
+	return defaultSignerProfile
+}
+
+func readPEMCertificates(path string) ([]*x509.Certificate, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s: no certificate found", path)
+	}
+	return certs, nil
+}
+
+func readPEMKey(path string) (interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			return nil, fmt.Errorf("%s: no private key found", path)
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+	}
+}
+
+// ---------- signing ----------
+
+const (
+	// ukuran awal /Contents (byte, sebelum hex); cukup untuk chain + timestamp umum
+	signatureReserve     = 8192
+	timestampReserve     = 8192
+	byteRangePlaceholder = "/ByteRange [0 0000000000 0000000000 0000000000]"
+)
+
+var reStartXref = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+
+// SignPDF adds a PAdES signature (approval signature, ETSI.CAdES.detached) to
+// pdf as an incremental update, so the signed bytes stay untouched.
+func (sg *Signer) SignPDF(ctx context.Context, pdf []byte, opts *docgenpb.PdfSignature) ([]byte, error) {
+	pc, err := api.ReadContext(bytes.NewReader(pdf), model.NewDefaultConfiguration())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "read pdf: %v", err)
+	}
+	if pc.Encrypt != nil {
+		return nil, status.Error(codes.Unimplemented, "signing encrypted PDFs is not supported")
+	}
+	if err := pc.EnsurePageCount(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "read pdf: %v", err)
+	}
+	prevXref := reStartXref.FindSubmatch(pdf)
+	if prevXref == nil || pc.Size == nil || pc.Root == nil {
+		return nil, status.Error(codes.InvalidArgument, "read pdf: no trailer")
+	}
+
+	u := &pdfUpdate{base: pdf, next: *pc.Size, xrefStream: pc.Read.UsingXRefStreams}
+	now := time.Now()
+	reason := firstNonEmpty(opts.GetReason(), sg.cfg.Reason)
+	location := firstNonEmpty(opts.GetLocation(), sg.cfg.Location)
+	contact := firstNonEmpty(opts.GetContactInfo(), sg.cfg.ContactInfo)
+
+	// 1) signature dictionary, /Contents & /ByteRange diisi belakangan
+	reserve := signatureReserve + len(sg.cert.Raw)
+	for _, c := range sg.chain {
+		reserve += len(c.Raw)
+	}
+	if opts.GetTimestamp() {
+		reserve += timestampReserve
+	}
+	var sigDict strings.Builder
+	sigDict.WriteString("<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /ETSI.CAdES.detached ")
+	sigDict.WriteString(byteRangePlaceholder)
+	sigDict.WriteString(" /Contents <" + strings.Repeat("0", 2*reserve) + ">")
+	sigDict.WriteString(" /M " + pdfText(types.DateString(now)))
+	sigDict.WriteString(" /Name " + pdfText(sg.cert.Subject.CommonName))
+	if reason != "" {
+		sigDict.WriteString(" /Reason " + pdfText(reason))
+	}
+	if location != "" {
+		sigDict.WriteString(" /Location " + pdfText(location))
+	}
+	if contact != "" {
+		sigDict.WriteString(" /ContactInfo " + pdfText(contact))
+	}
+	sigDict.WriteString(" >>")
+	sigRef := u.add(sigDict.String())
+
+	// 2) widget annotation + field di halaman yang dipilih
+	pageNr := pc.PageCount
+	rect := [4]float64{0, 0, 0, 0}
+	app := opts.GetAppearance()
+	if app != nil {
+		if app.GetPage() != 0 {
+			pageNr = int(app.GetPage())
+		}
+		if pageNr < 1 || pageNr > pc.PageCount {
+			return nil, status.Errorf(codes.InvalidArgument, "appearance page %d out of range (1-%d)", pageNr, pc.PageCount)
+		}
+		w, h := app.GetWidth(), app.GetHeight()
+		if w == 0 {
+			w = 200
+		}
+		if h == 0 {
+			h = 60
+		}
+		if w < 0 || h < 0 {
+			return nil, status.Error(codes.InvalidArgument, "appearance width and height must be positive")
+		}
+		rect = [4]float64{app.GetX(), app.GetY(), app.GetX() + w, app.GetY() + h}
+	}
+	pageDict, pageRef, _, err := pc.PageDict(pageNr, false)
+	if err != nil || pageRef == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "read page %d: %v", pageNr, err)
+	}
+
+	fields, acroForm, acroRef, err := acroFormFields(pc)
+	if err != nil {
+		return nil, err
+	}
+	widget := fmt.Sprintf("<< /Type /Annot /Subtype /Widget /FT /Sig /T %s /F 132 /Rect [%s %s %s %s] /P %s /V %s",
+		pdfText(fmt.Sprintf("Signature%d", len(fields)+1)), pdfNum(rect[0]), pdfNum(rect[1]), pdfNum(rect[2]), pdfNum(rect[3]), pageRef.PDFString(), sigRef.PDFString())
+	if app != nil {
+		lines := strings.Split(app.GetText(), "\n")
+		if app.GetText() == "" {
+			lines = []string{"Ditandatangani secara digital oleh", sg.cert.Subject.CommonName, "Waktu: " + now.Format("2006-01-02 15:04:05 -07:00")}
+			if reason != "" {
+				lines = append(lines, "Alasan: "+reason)
+			}
+			if location != "" {
+				lines = append(lines, "Lokasi: "+location)
+			}
+		}
+		font := u.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+		ap := u.addStream(fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [0 0 %s %s] /Resources << /Font << /F1 %s >> >>",
+			pdfNum(rect[2]-rect[0]), pdfNum(rect[3]-rect[1]), font.PDFString()), appearanceStream(rect[2]-rect[0], rect[3]-rect[1], lines))
+		widget += " /AP << /N " + ap.PDFString() + " >>"
+	}
+	widgetRef := u.add(widget + " >>")
+
+	annots, err := pc.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "read page annotations: %v", err)
+	}
+	page := pageDict.Clone().(types.Dict)
+	page["Annots"] = append(append(types.Array{}, annots...), widgetRef)
+	u.replace(*pageRef, page.PDFString())
+
+	// 3) AcroForm dengan field baru; /SigFlags 3 = SignaturesExist | AppendOnly
+	acroForm["Fields"] = append(fields, widgetRef)
+	acroForm["SigFlags"] = types.Integer(3)
+	if acroRef != nil {
+		u.replace(*acroRef, acroForm.PDFString())
+	} else {
+		root := pc.RootDict.Clone().(types.Dict)
+		root["AcroForm"] = acroForm
+		u.replace(*pc.Root, root.PDFString())
+	}
+
+	// 4) tulis update, lalu isi /ByteRange dan /Contents
+	trailer := fmt.Sprintf("/Root %s /Prev %s", pc.Root.PDFString(), prevXref[1])
+	if pc.Info != nil {
+		trailer += " /Info " + pc.Info.PDFString()
+	}
+	if len(pc.ID) > 0 {
+		trailer += " /ID " + pc.ID.PDFString()
+	}
+	out, err := u.write(trailer)
+	if err != nil {
+		return nil, err
+	}
+
+	sigObj := u.offsets[sigRef.ObjectNumber.Value()]
+	brStart := sigObj + bytes.Index(out[sigObj:], []byte(byteRangePlaceholder))
+	cStart := sigObj + bytes.Index(out[sigObj:], []byte("/Contents <")) + len("/Contents ")
+	cEnd := cStart + 2*reserve + 2
+	br := fmt.Sprintf("/ByteRange [0 %d %d %d]", cStart, cEnd, len(out)-cEnd)
+	copy(out[brStart:], br+strings.Repeat(" ", len(byteRangePlaceholder)-len(br)))
+
+	signed := make([]byte, 0, len(out)-(cEnd-cStart))
+	signed = append(append(signed, out[:cStart]...), out[cEnd:]...)
+	var tsa Timestamper
+	if opts.GetTimestamp() {
+		tsa = sg.tsa
+	}
+	cms, err := signCMS(ctx, signed, sg.cert, sg.chain, sg.key, tsa)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "sign pdf: %v", err)
+	}
+	if len(cms) > reserve {
+		return nil, status.Errorf(codes.Internal, "sign pdf: signature is %d bytes, reserved %d", len(cms), reserve)
+	}
+	copy(out[cStart+1:], strings.ToUpper(hex.EncodeToString(cms)))
+	return out, nil
+}
+
+// acroFormFields returns the document's AcroForm (a copy) with its field
+// list, and the AcroForm's object reference when it is an indirect object.
+func acroFormFields(pc *model.Context) (types.Array, types.Dict, *types.IndirectRef, error) {
+	obj, ok := pc.RootDict.Find("AcroForm")
+	if !ok {
+		return nil, types.Dict{}, nil, nil
+	}
+	var ref *types.IndirectRef
+	if ir, ok := obj.(types.IndirectRef); ok {
+		ref = &ir
+	}
+	d, err := pc.DereferenceDict(obj)
+	if err != nil || d == nil {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "read AcroForm: %v", err)
+	}
+	d = d.Clone().(types.Dict)
+	fields, err := pc.DereferenceArray(d["Fields"])
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "read AcroForm fields: %v", err)
+	}
+	return append(types.Array{}, fields...), d, ref, nil
+}
+
+// appearanceStream draws a thin border and the lines of text, scaled to fit.
+func appearanceStream(w, h float64, lines []string) string {
+	size := 9.0
+	if fit := (h - 6) / (1.2 * float64(len(lines))); fit < size {
+		size = fit
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "q 0.5 w 0 0 0 RG 0.25 0.25 %s %s re S Q\n", pdfNum(w-0.5), pdfNum(h-0.5))
+	fmt.Fprintf(&b, "BT /F1 %s Tf %s TL 4 %s Td\n", pdfNum(size), pdfNum(size*1.2), pdfNum(h-3-size))
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("T* ")
+		}
+		b.WriteString(pdfLatin1(line) + " Tj\n")
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// ---------- incremental update ----------
+
+// pdfUpdate collects objects for an incremental update appended to base.
+type pdfUpdate struct {
+	base       []byte
+	next       int // next free object number
+	xrefStream bool
+	objs       []pdfUpdateObj
+	offsets    map[int]int // object number -> offset in the written file
+}
+
+type pdfUpdateObj struct {
+	nr, gen int
+	body    string
+}
+
+// add appends a new object and returns its reference.
+func (u *pdfUpdate) add(body string) types.IndirectRef {
+	nr := u.next
+	u.next++
+	u.objs = append(u.objs, pdfUpdateObj{nr: nr, body: body})
+	return *types.NewIndirectRef(nr, 0)
+}
+
+func (u *pdfUpdate) addStream(dict, data string) types.IndirectRef {
+	return u.add(fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data))
+}
+
+// replace writes a new version of an existing object.
+func (u *pdfUpdate) replace(ref types.IndirectRef, body string) {
+	u.objs = append(u.objs, pdfUpdateObj{nr: ref.ObjectNumber.Value(), gen: ref.GenerationNumber.Value(), body: body})
+}
+
+// write returns base + the update: objects, cross-reference section (a table,
+// or a stream when base uses xref streams) and trailer entries.
+func (u *pdfUpdate) write(trailer string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(u.base)
+	if !bytes.HasSuffix(u.base, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	offsets := map[int]int{}
+	gens := map[int]int{}
+	for _, o := range u.objs {
+		offsets[o.nr] = buf.Len()
+		gens[o.nr] = o.gen
+		fmt.Fprintf(&buf, "%d %d obj\n%s\nendobj\n", o.nr, o.gen, o.body)
+	}
+
+	if !u.xrefStream {
+		xref := buf.Len()
+		buf.WriteString("xref\n")
+		for _, sec := range xrefSections(offsets) {
+			fmt.Fprintf(&buf, "%d %d\n", sec[0], sec[1])
+			for nr := sec[0]; nr < sec[0]+sec[1]; nr++ {
+				fmt.Fprintf(&buf, "%010d %05d n\r\n", offsets[nr], gens[nr])
+			}
+		}
+		fmt.Fprintf(&buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", u.next, trailer, xref)
+		u.offsets = offsets
+		return buf.Bytes(), nil
+	}
+
+	// xref stream: entri type 1, offset 4 byte, generation 2 byte
+	nr := u.next
+	u.next++
+	xref := buf.Len()
+	offsets[nr] = xref
+	var data bytes.Buffer
+	var index []string
+	for _, sec := range xrefSections(offsets) {
+		index = append(index, strconv.Itoa(sec[0]), strconv.Itoa(sec[1]))
+		for n := sec[0]; n < sec[0]+sec[1]; n++ {
+			off, gen := offsets[n], gens[n]
+			data.Write([]byte{1, byte(off >> 24), byte(off >> 16), byte(off >> 8), byte(off), byte(gen >> 8), byte(gen)})
+		}
+	}
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /XRef /Size %d /Index [%s] /W [1 4 2] %s /Length %d >>\nstream\n",
+		nr, u.next, strings.Join(index, " "), trailer, data.Len())
+	buf.Write(data.Bytes())
+	fmt.Fprintf(&buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", xref)
+	u.offsets = offsets
+	return buf.Bytes(), nil
+}
+
+// xrefSections groups object numbers into [first, count] runs.
+func xrefSections(offsets map[int]int) [][2]int {
+	nrs := make([]int, 0, len(offsets))
+	for nr := range offsets {
+		nrs = append(nrs, nr)
+	}
+	sort.Ints(nrs)
+	var secs [][2]int
+	for _, nr := range nrs {
+		if n := len(secs); n > 0 && secs[n-1][0]+secs[n-1][1] == nr {
+			secs[n-1][1]++
+			continue
+		}
+		secs = append(secs, [2]int{nr, 1})
+	}
+	return secs
+}
+
+// ---------- PDF syntax helpers ----------
+
+func pdfNum(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// pdfText encodes a text string: a literal for printable ASCII, UTF-16BE hex otherwise.
+func pdfText(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return pdfLiteral(s)
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, c := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", c)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// pdfLatin1 encodes s as a literal for a WinAnsi font; other runes become '?'.
+func pdfLatin1(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return pdfLiteral(string(b))
+}
+
+func pdfLiteral(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return "(" + r.Replace(s) + ")"
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
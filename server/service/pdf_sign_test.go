@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newTestCert issues a certificate from parent, or a self-signed CA when
+// parent is nil.
+func newTestCert(t *testing.T, cn string, parent *testCert, eku ...x509.ExtKeyUsage) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Docgen Test"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  eku,
+	}
+	signer, issuer := crypto.Signer(key), tpl
+	if parent == nil {
+		tpl.IsCA, tpl.BasicConstraintsValid = true, true
+		tpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, issuer = parent.key, parent.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, issuer, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+// writePEM writes the certificate and key of c to dir and returns the paths.
+func (c *testCert) writePEM(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, c.cert.Subject.CommonName+".crt")
+	keyFile = filepath.Join(dir, c.cert.Subject.CommonName+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+type signFixture struct {
+	ca     *testCert
+	signer *Signer
+	svc    *DocService
+}
+
+func newSignFixture(t *testing.T) *signFixture {
+	t.Helper()
+	ca := newTestCert(t, "Test Root", nil)
+	leaf := newTestCert(t, "kantor", ca)
+	certFile, keyFile := leaf.writePEM(t, t.TempDir())
+	sg, err := NewSigner(SignerConfig{Name: "kantor", CertFile: certFile, KeyFile: keyFile, Reason: "Disetujui", Location: "Jakarta"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	svc := NewDocService(nil, WithSigners(map[string]*Signer{"kantor": sg}), WithTrustRoots(roots))
+	return &signFixture{ca: ca, signer: sg, svc: svc}
+}
+
+func (f *signFixture) verify(t *testing.T, pdf []byte) *docgenpb.VerifyPDFResponse {
+	t.Helper()
+	resp, err := f.svc.VerifyPDF(context.Background(), &docgenpb.VerifyPDFRequest{Pdf: pdf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Signatures) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(resp.Signatures))
+	}
+	return resp
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	f := newSignFixture(t)
+	signed, err := f.signer.SignPDF(context.Background(), samplePDF, &docgenpb.PdfSignature{
+		Appearance: &docgenpb.SignatureAppearance{Page: 1, X: 350, Y: 60},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(signed, samplePDF) {
+		t.Fatal("signature is not an incremental update of the original")
+	}
+	resp := f.verify(t, signed)
+	v := resp.Signatures[0]
+	if !resp.Valid || !v.Intact || !v.Trusted || v.ModifiedAfterSigning {
+		t.Fatalf("valid=%v intact=%v trusted=%v modified=%v problems=%v", resp.Valid, v.Intact, v.Trusted, v.ModifiedAfterSigning, v.Problems)
+	}
+	if v.SignerName != "kantor" || v.SignerProfile != "kantor" || v.Reason != "Disetujui" || v.Location != "Jakarta" {
+		t.Errorf("signer=%q profile=%q reason=%q location=%q", v.SignerName, v.SignerProfile, v.Reason, v.Location)
+	}
+	if v.SubFilter != "ETSI.CAdES.detached" {
+		t.Errorf("sub filter %q", v.SubFilter)
+	}
+}
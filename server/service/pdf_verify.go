@@ -0,0 +1,347 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/digitorus/pkcs7"
+	"github.com/digitorus/timestamp"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithTrustRoots sets the CA certificates VerifyPDF accepts as trust anchors
+// for signer and timestamp certificates.
+func WithTrustRoots(roots *x509.CertPool) Option {
+	return func(s *DocService) { s.roots = roots }
+}
+
+// LoadCertPool reads a PEM bundle of CA certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	certs, err := readPEMCertificates(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c)
+	}
+	return pool, nil
+}
+
+func (s *DocService) VerifyPDF(ctx context.Context, req *docgenpb.VerifyPDFRequest) (*docgenpb.VerifyPDFResponse, error) {
+	pdf := req.GetPdf()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		return nil, status.Error(codes.InvalidArgument, "not a PDF")
+	}
+	sum := sha256.Sum256(pdf)
+	resp := &docgenpb.VerifyPDFResponse{Sha256: hex.EncodeToString(sum[:])}
+	if rec, ok := s.lookup(ctx, resp.Sha256); ok {
+		resp.Recorded = true
+		resp.RecordedAt = rec.CreatedAt.Format(time.RFC3339)
+		resp.DocumentCode = rec.Code
+	}
+
+	sigs, err := pdfSignatures(pdf)
+	if err != nil {
+		return nil, err
+	}
+	resp.Valid = len(sigs) > 0
+	for _, sig := range sigs {
+		v := s.verifySignature(ctx, pdf, sig)
+		resp.Signatures = append(resp.Signatures, v)
+		resp.Valid = resp.Valid && v.Intact && v.Trusted
+	}
+	if n := len(resp.Signatures); n > 0 && resp.Signatures[n-1].ModifiedAfterSigning {
+		resp.Valid = false
+	}
+	return resp, nil
+}
+
+// lookup finds a recorded document the caller may see: tenants only find
+// their own documents.
+func (s *DocService) lookup(ctx context.Context, sha string) (DocumentRecord, bool) {
+	if s.registry == nil {
+		return DocumentRecord{}, false
+	}
+	for _, rec := range s.registry.Lookup(sha) {
+		if visibleTo(ctx, rec.Tenant) {
+			return rec, true
+		}
+	}
+	return DocumentRecord{}, false
+}
+
+// pdfSignature is a signature field's value as found in the document.
+type pdfSignature struct {
+	field      string
+	subFilter  string
+	byteRange  []int
+	signedAt   string // /M
+	reason     string
+	location   string
+	parseError string
+}
+
+// end is the offset where the signed revision ends.
+func (p pdfSignature) end() int {
+	if len(p.byteRange) != 4 {
+		return 0
+	}
+	return p.byteRange[2] + p.byteRange[3]
+}
+
+// pdfSignatures lists the signed signature fields of pdf, in the order they
+// were signed.
+func pdfSignatures(pdf []byte) ([]pdfSignature, error) {
+	pc, err := api.ReadContext(bytes.NewReader(pdf), model.NewDefaultConfiguration())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "read pdf: %v", err)
+	}
+	if pc.Encrypt != nil {
+		return nil, status.Error(codes.Unimplemented, "verifying encrypted PDFs is not supported")
+	}
+	fields, _, _, err := acroFormFields(pc)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigs []pdfSignature
+	// /FT dan /T bisa diwarisi dari parent (field hierarki)
+	var walk func(objs types.Array, parent, ft string, depth int)
+	walk = func(objs types.Array, parent, ft string, depth int) {
+		if depth > 16 {
+			return
+		}
+		for _, o := range objs {
+			d, err := pc.DereferenceDict(o)
+			if err != nil || d == nil {
+				continue
+			}
+			name, fieldType := parent, ft
+			if t := pdfDictText(pc, d, "T"); t != "" {
+				if name != "" {
+					name += "."
+				}
+				name += t
+			}
+			if n := d.NameEntry("FT"); n != nil {
+				fieldType = *n
+			}
+			if fieldType == "Sig" && d["V"] != nil {
+				sigs = append(sigs, readSignatureDict(pc, name, d["V"]))
+			}
+			if kids, err := pc.DereferenceArray(d["Kids"]); err == nil && kids != nil {
+				walk(kids, name, fieldType, depth+1)
+			}
+		}
+	}
+	walk(fields, "", "", 0)
+	sort.SliceStable(sigs, func(i, j int) bool { return sigs[i].end() < sigs[j].end() })
+	return sigs, nil
+}
+
+func readSignatureDict(pc *model.Context, field string, obj types.Object) pdfSignature {
+	sig := pdfSignature{field: field}
+	v, err := pc.DereferenceDict(obj)
+	if err != nil || v == nil {
+		sig.parseError = "signature dictionary is unreadable"
+		return sig
+	}
+	if n := v.NameEntry("SubFilter"); n != nil {
+		sig.subFilter = *n
+	}
+	sig.signedAt = pdfDictText(pc, v, "M")
+	sig.reason = pdfDictText(pc, v, "Reason")
+	sig.location = pdfDictText(pc, v, "Location")
+	br, err := pc.DereferenceArray(v["ByteRange"])
+	if err != nil || len(br) != 4 {
+		sig.parseError = "invalid ByteRange"
+		return sig
+	}
+	for _, o := range br {
+		i, ok := o.(types.Integer)
+		if !ok {
+			sig.parseError = "invalid ByteRange"
+			return sig
+		}
+		sig.byteRange = append(sig.byteRange, i.Value())
+	}
+	return sig
+}
+
+func pdfDictText(pc *model.Context, d types.Dict, key string) string {
+	o, ok := d.Find(key)
+	if !ok {
+		return ""
+	}
+	s, err := pc.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// verifySignature checks one signature: the byte range, the CMS signature
+// over it, an embedded RFC 3161 timestamp and the signer's certificate chain.
+func (s *DocService) verifySignature(ctx context.Context, pdf []byte, sig pdfSignature) *docgenpb.SignatureVerification {
+	v := &docgenpb.SignatureVerification{
+		FieldName: sig.field,
+		SubFilter: sig.subFilter,
+		Reason:    sig.reason,
+		Location:  sig.location,
+	}
+	problem := func(format string, args ...interface{}) {
+		v.Problems = append(v.Problems, fmt.Sprintf(format, args...))
+	}
+	if t, ok := types.DateTime(sig.signedAt, true); ok {
+		v.SigningTime = t.Format(time.RFC3339)
+	}
+	if sig.parseError != "" {
+		problem("%s", sig.parseError)
+		return v
+	}
+	if sig.subFilter != "adbe.pkcs7.detached" && sig.subFilter != "ETSI.CAdES.detached" {
+		problem("sub filter %q is not supported", sig.subFilter)
+		return v
+	}
+
+	// ByteRange harus menutup semua byte kecuali /Contents <...>
+	br := sig.byteRange
+	if br[0] != 0 || br[1] <= 0 || br[2] <= br[1]+1 || br[3] < 0 || sig.end() > len(pdf) ||
+		pdf[br[1]] != '<' || pdf[br[2]-1] != '>' {
+		problem("invalid ByteRange %v", br)
+		return v
+	}
+	v.ModifiedAfterSigning = sig.end() != len(pdf)
+	revision := sha256.Sum256(pdf[:sig.end()])
+	_, v.RevisionRecorded = s.lookup(ctx, hex.EncodeToString(revision[:]))
+
+	// /Contents diisi nol setelah DER; sisa itu diabaikan asn1.Unmarshal
+	der, err := hex.DecodeString(string(pdf[br[1]+1 : br[2]-1]))
+	var raw asn1.RawValue
+	if err == nil {
+		_, err = asn1.Unmarshal(der, &raw)
+	}
+	if err != nil {
+		problem("signature contents are not DER: %v", err)
+		return v
+	}
+	p7, err := pkcs7.Parse(raw.FullBytes)
+	if err != nil {
+		problem("parse signature: %v", err)
+		return v
+	}
+	cert := p7.GetOnlySigner()
+	if cert == nil {
+		problem("signature must have exactly one signer with its certificate")
+		return v
+	}
+	v.SignerName = cert.Subject.CommonName
+	v.SignerSubject = cert.Subject.String()
+	v.Issuer = cert.Issuer.String()
+	for name, sg := range s.signers {
+		if bytes.Equal(sg.cert.Raw, cert.Raw) && (sg.cfg.Tenant == "" || visibleTo(ctx, sg.cfg.Tenant)) {
+			v.SignerProfile = name
+		}
+	}
+
+	p7.Content = make([]byte, 0, br[1]+br[3])
+	p7.Content = append(append(p7.Content, pdf[:br[1]]...), pdf[br[2]:sig.end()]...)
+	var mismatch *pkcs7.MessageDigestMismatchError
+	if err := p7.Verify(); errors.As(err, &mismatch) {
+		problem("signed bytes were changed (message digest mismatch)")
+	} else if err != nil {
+		problem("signature does not match the document: %v", err)
+	} else {
+		v.Intact = true
+	}
+
+	// sertifikat dicek pada waktu timestamp bila TSA-nya terpercaya, selain itu sekarang
+	at := time.Now()
+	if tsTime, err := s.signatureTimestamp(p7); err != nil {
+		problem("timestamp: %v", err)
+	} else if !tsTime.IsZero() {
+		v.TimestampTime = tsTime.Format(time.RFC3339)
+		at = tsTime
+	}
+	if err := s.chainTrusted(cert, p7.Certificates, at, x509.ExtKeyUsageAny); err != nil {
+		problem("signer certificate is not trusted: %v", err)
+	} else {
+		v.Trusted = true
+	}
+	return v
+}
+
+// signatureTimestamp returns the time of the RFC 3161 token embedded in the
+// (single) signer info, zero if there is none. The token must cover the
+// signature value and come from a trusted TSA.
+func (s *DocService) signatureTimestamp(p7 *pkcs7.PKCS7) (time.Time, error) {
+	si := p7.Signers[0]
+	for _, attr := range si.UnauthenticatedAttributes {
+		if !attr.Type.Equal(oidAttrTimestampToken) {
+			continue
+		}
+		ts, err := timestamp.Parse(attr.Value.Bytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !ts.HashAlgorithm.Available() {
+			return time.Time{}, fmt.Errorf("unsupported hash %v", ts.HashAlgorithm)
+		}
+		h := ts.HashAlgorithm.New()
+		h.Write(si.EncryptedDigest)
+		if !bytes.Equal(h.Sum(nil), ts.HashedMessage) {
+			return time.Time{}, errors.New("token does not cover this signature")
+		}
+		tp7, err := pkcs7.Parse(attr.Value.Bytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+		tsa := tp7.GetOnlySigner()
+		if tsa == nil {
+			return time.Time{}, errors.New("token has no TSA certificate")
+		}
+		// RFC 3161 §2.3: sertifikat TSA hanya boleh ber-EKU timeStamping, jadi
+		// sertifikat signer tidak bisa membuat token dengan waktu sembarang
+		if len(tsa.ExtKeyUsage) != 1 || tsa.ExtKeyUsage[0] != x509.ExtKeyUsageTimeStamping || len(tsa.UnknownExtKeyUsage) > 0 {
+			return time.Time{}, errors.New("TSA certificate must have timeStamping as its only extended key usage")
+		}
+		if err := s.chainTrusted(tsa, tp7.Certificates, ts.Time, x509.ExtKeyUsageTimeStamping); err != nil {
+			return time.Time{}, fmt.Errorf("timestamp authority is not trusted: %v", err)
+		}
+		return ts.Time, nil
+	}
+	return time.Time{}, nil
+}
+
+// chainTrusted verifies cert against the trust roots at time at; every
+// certificate of the chain must allow usage.
+func (s *DocService) chainTrusted(cert *x509.Certificate, pool []*x509.Certificate, at time.Time, usage x509.ExtKeyUsage) error {
+	if s.roots == nil {
+		return errors.New("no trust roots configured on this server")
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range pool {
+		intermediates.AddCert(c)
+	}
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         s.roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+)
+
+func TestVerifyDetectsChanges(t *testing.T) {
+	f := newSignFixture(t)
+	signed, err := f.signer.SignPDF(context.Background(), samplePDF, &docgenpb.PdfSignature{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := bytes.Replace(signed, []byte("Surat Keterangan"), []byte("Surat Keterangam"), 1)
+	resp := f.verify(t, tampered)
+	if resp.Valid || resp.Signatures[0].Intact {
+		t.Errorf("changed signed bytes verify as intact: %v", resp.Signatures[0].Problems)
+	}
+
+	appended := append(append([]byte(nil), signed...), "\n1 0 obj\n<< >>\nendobj\n"...)
+	resp = f.verify(t, appended)
+	if resp.Valid || !resp.Signatures[0].ModifiedAfterSigning || !resp.Signatures[0].Intact {
+		t.Errorf("bytes after the signature: valid=%v modified=%v intact=%v",
+			resp.Valid, resp.Signatures[0].ModifiedAfterSigning, resp.Signatures[0].Intact)
+	}
+}
+
+func TestVerifyUntrustedSigner(t *testing.T) {
+	f := newSignFixture(t)
+	signed, err := f.signer.SignPDF(context.Background(), samplePDF, &docgenpb.PdfSignature{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := x509.NewCertPool()
+	other.AddCert(newTestCert(t, "Other Root", nil).cert)
+	f.svc.roots = other
+	resp := f.verify(t, signed)
+	if resp.Valid || resp.Signatures[0].Trusted || !resp.Signatures[0].Intact {
+		t.Errorf("valid=%v trusted=%v intact=%v", resp.Valid, resp.Signatures[0].Trusted, resp.Signatures[0].Intact)
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	f := newSignFixture(t)
+	tsa := newTestCert(t, "Test TSA", f.ca, x509.ExtKeyUsageTimeStamping)
+	mixed := newTestCert(t, "Mixed TSA", f.ca, x509.ExtKeyUsageTimeStamping, x509.ExtKeyUsageClientAuth)
+
+	tests := []struct {
+		name    string
+		tsa     Timestamper
+		trusted bool
+	}{
+		{"timeStamping TSA", &LocalTimestamper{Cert: tsa.cert, Key: tsa.key}, true},
+		// tsa_url "local": token dari sertifikat signer sendiri tidak boleh dipercaya
+		{"signer certificate", &LocalTimestamper{Cert: f.signer.cert, Key: f.signer.key}, false},
+		{"extra extended key usage", &LocalTimestamper{Cert: mixed.cert, Key: mixed.key}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.signer.tsa = tt.tsa
+			signed, err := f.signer.SignPDF(context.Background(), samplePDF, &docgenpb.PdfSignature{Timestamp: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := f.verify(t, signed).Signatures[0]
+			if got := v.TimestampTime != ""; got != tt.trusted {
+				t.Fatalf("timestamp trusted = %v, want %v (problems %v)", got, tt.trusted, v.Problems)
+			}
+			if !tt.trusted && !strings.Contains(strings.Join(v.Problems, "; "), "timestamp") {
+				t.Errorf("no timestamp problem reported: %v", v.Problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultPreviewDPI = 96
+	maxPreviewDPI     = 300
+	maxPreviewWidth   = 4096
+	maxPreviewPages   = 20
+)
+
+// RenderPreview converts the template (placeholders highlighted) or a filled
+// document to PDF through the usual LibreOffice pipeline, then rasterizes the
+// selected pages. The PDF is cached per template hash, so asking for other
+// pages or sizes of the same document does not convert it again.
+func (s *DocService) RenderPreview(ctx context.Context, req *docgenpb.PreviewRequest) (*docgenpb.PreviewResponse, error) {
+	imgFormat := req.GetImageFormat()
+	switch imgFormat {
+	case docgenpb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED:
+		imgFormat = docgenpb.OutputFormat_OUTPUT_FORMAT_PNG
+	case docgenpb.OutputFormat_OUTPUT_FORMAT_PNG, docgenpb.OutputFormat_OUTPUT_FORMAT_JPEG:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "preview image_format must be PNG or JPEG, got %v", imgFormat)
+	}
+	dpi := int(req.GetDpi())
+	if dpi == 0 {
+		dpi = defaultPreviewDPI
+	} else if dpi < 24 || dpi > maxPreviewDPI {
+		return nil, status.Errorf(codes.InvalidArgument, "dpi must be 24-%d, got %d", maxPreviewDPI, dpi)
+	}
+	if w := req.GetWidth(); w < 0 || w > maxPreviewWidth || (w > 0 && w < 16) {
+		return nil, status.Errorf(codes.InvalidArgument, "width must be 16-%d px, got %d", maxPreviewWidth, w)
+	}
+	selection := req.GetPages()
+	if selection == "" {
+		selection = "1"
+	}
+	sel, err := api.ParsePageSelection(selection)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "preview pages: %v", err)
+	}
+
+	var pdf *docgenpb.GenerateResponse
+	switch src := req.GetSource().(type) {
+	case *docgenpb.PreviewRequest_Template:
+		pdf, err = s.templatePreviewPDF(ctx, src.Template)
+	case *docgenpb.PreviewRequest_Generate:
+		pdf, err = s.documentPreviewPDF(ctx, src.Generate)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "template or generate is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := api.PageCount(bytes.NewReader(pdf.GetContent()), nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "page count: %v", err)
+	}
+	set, err := api.PagesForPageSelection(count, sel, false, false)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "preview pages: %v", err)
+	}
+	var pages []int
+	for p, ok := range set {
+		if ok {
+			pages = append(pages, p)
+		}
+	}
+	sort.Ints(pages)
+	if len(pages) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "pages %q select nothing in a %d-page document", selection, count)
+	}
+	if len(pages) > maxPreviewPages {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d pages per preview, %q selects %d", maxPreviewPages, selection, len(pages))
+	}
+
+	images, err := rasterizePDF(ctx, pdf.GetContent(), pages, dpi, int(req.GetWidth()), outputFormats[imgFormat])
+	if err != nil {
+		return nil, err
+	}
+	return &docgenpb.PreviewResponse{Pages: images, PageCount: int32(count)}, nil
+}
+
+// templatePreviewPDF renders the raw template with its placeholders highlighted.
+func (s *DocService) templatePreviewPDF(ctx context.Context, tpl []byte) (*docgenpb.GenerateResponse, error) {
+	if len(tpl) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "template is empty")
+	}
+	if err := s.checkDocx("template", tpl); err != nil {
+		return nil, err
+	}
+	tpl, _, err := s.sanitizeInput("template", tpl)
+	if err != nil {
+		return nil, err
+	}
+	// key cache = hash template saja
+	key := &docgenpb.GenerateRequest{Template: tpl}
+	return s.cached(ctx, "preview-template", key, func() (*docgenpb.GenerateResponse, error) {
+		marked, err := highlightPlaceholders(tpl)
+		if err != nil {
+			return nil, err
+		}
+		tmp, err := writeTemp("preview", ".docx", marked)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmp)
+		return s.wp.SubmitJob(ctx, func() (*docgenpb.GenerateResponse, error) {
+			content, err := convertDocxToPDF(tmp, tenantFontDirs(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return &docgenpb.GenerateResponse{Content: content, ContentType: "application/pdf"}, nil
+		})
+	})
+}
+
+// documentPreviewPDF renders a generate request as PDF. Only the look of the
+// document matters, so security, signature and verification are dropped and
+// the preview is not recorded; it shares cache entries with GeneratePDF.
+func (s *DocService) documentPreviewPDF(ctx context.Context, req *docgenpb.GenerateRequest) (*docgenpb.GenerateResponse, error) {
+	if len(req.GetTemplate()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "template is empty")
+	}
+	if err := s.checkDocx("template", req.GetTemplate()); err != nil {
+		return nil, err
+	}
+	tpl, _, err := s.sanitizeInput("template", req.GetTemplate())
+	if err != nil {
+		return nil, err
+	}
+	r := proto.Clone(withTenantDefaults(ctx, req, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF)).(*docgenpb.GenerateRequest)
+	r.Template = tpl
+	r.Security = nil
+	r.Signature = nil
+	r.Verification = nil
+	r.IdempotencyKey = ""
+
+	out := outputFormats[docgenpb.OutputFormat_OUTPUT_FORMAT_PDF]
+	if r.GetPdf() != nil {
+		filter, err := pdfExportFilter(r.GetPdf())
+		if err != nil {
+			return nil, err
+		}
+		out.filter = filter
+	}
+	return s.cached(ctx, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF.String(), r, func() (*docgenpb.GenerateResponse, error) {
+		return s.render(ctx, r, out)
+	})
+}
+
+// ---------- placeholder highlight ----------
+
+// reRunToken finds, in document order, run starts, run text and paragraph ends.
+var reRunToken = regexp.MustCompile(`<w:r[ >]|<w:t(?:\s[^>]*)?>([^<]*)</w:t>|</w:p>`)
+
+const highlightXML = `<w:highlight w:val="yellow"/>`
+
+// highlightPlaceholders marks every run that holds part of a {placeholder}
+// with a yellow highlight, in the body, headers and footers. Placeholders are
+// often split over several runs by Word; all of them are marked.
+func highlightPlaceholders(docxBytes []byte) ([]byte, error) {
+	pkg, err := openDocxPackage(docxBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "template: %v", err)
+	}
+	for _, name := range pkg.names {
+		if name != mainDocumentPart && !reHeaderFooterPart.MatchString(name) {
+			continue
+		}
+		part, _ := pkg.get(name)
+		pkg.set(name, highlightRuns(part))
+	}
+	return pkg.bytes()
+}
+
+var reHeaderFooterPart = regexp.MustCompile(`^word/(header|footer)\d*\.xml$`)
+
+func highlightRuns(part []byte) []byte {
+	doc := string(part)
+	var (
+		text    strings.Builder
+		owners  []int // per byte teks paragraf: offset <w:r pemiliknya
+		run     = -1
+		targets = map[int]bool{}
+	)
+	for _, m := range reRunToken.FindAllStringSubmatchIndex(doc, -1) {
+		tok := doc[m[0]:m[1]]
+		switch {
+		case strings.HasPrefix(tok, "</w:p>"):
+			for _, loc := range rePH.FindAllStringIndex(text.String(), -1) {
+				for i := loc[0]; i < loc[1]; i++ {
+					if owners[i] >= 0 {
+						targets[owners[i]] = true
+					}
+				}
+			}
+			text.Reset()
+			owners = owners[:0]
+		case strings.HasPrefix(tok, "<w:t"):
+			t := doc[m[2]:m[3]]
+			text.WriteString(t)
+			for i := 0; i < len(t); i++ {
+				owners = append(owners, run)
+			}
+		default:
+			run = m[0]
+		}
+	}
+	if len(targets) == 0 {
+		return part
+	}
+
+	starts := make([]int, 0, len(targets))
+	for i := range targets {
+		starts = append(starts, i)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(starts)))
+	for _, i := range starts {
+		end := i + strings.IndexByte(doc[i:], '>') + 1
+		if doc[end-2] == '/' {
+			continue // <w:r/> kosong
+		}
+		rest := doc[end:]
+		switch {
+		case strings.HasPrefix(rest, "<w:rPr/>"):
+			doc = doc[:end] + "<w:rPr>" + highlightXML + "</w:rPr>" + rest[len("<w:rPr/>"):]
+		case strings.HasPrefix(rest, "<w:rPr>") || strings.HasPrefix(rest, "<w:rPr "):
+			if j := strings.Index(rest, "</w:rPr>"); j >= 0 {
+				doc = doc[:end] + rest[:j] + highlightXML + rest[j:]
+			}
+		default:
+			doc = doc[:end] + "<w:rPr>" + highlightXML + "</w:rPr>" + rest
+		}
+	}
+	return []byte(doc)
+}
+
+// ---------- rasterize ----------
+
+func detectPdftoppm() (string, error) {
+	for _, c := range []string{"pdftoppm", "/usr/bin/pdftoppm", "/usr/local/bin/pdftoppm", "/opt/homebrew/bin/pdftoppm"} {
+		if abs, err := exec.LookPath(c); err == nil {
+			return abs, nil
+		}
+	}
+	return "", fmt.Errorf("pdftoppm (poppler-utils) not found in PATH")
+}
+
+// pageImage renders a one-page PDF as out (PNG or JPEG) at the default
+// preview resolution. Longer documents are rejected rather than cut off.
+func pageImage(ctx context.Context, pdf []byte, out outputFormat) ([]byte, error) {
+	count, err := api.PageCount(bytes.NewReader(pdf), nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "page count: %v", err)
+	}
+	if count != 1 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"%s output holds a single page but the document has %d; use PDF or RenderPreview for every page", out.ext, count)
+	}
+	pages, err := rasterizePDF(ctx, pdf, []int{1}, defaultPreviewDPI, 0, out)
+	if err != nil {
+		return nil, err
+	}
+	return pages[0].Image, nil
+}
+
+// rasterizePDF renders each page with pdftoppm, at dpi or scaled to width
+// pixels when width > 0.
+func rasterizePDF(ctx context.Context, pdf []byte, pages []int, dpi, width int, out outputFormat) ([]*docgenpb.PreviewPage, error) {
+	bin, err := detectPdftoppm()
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	dir, err := os.MkdirTemp("", "preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "in.pdf")
+	if err := os.WriteFile(in, pdf, 0o600); err != nil {
+		return nil, err
+	}
+
+	args := []string{"-singlefile"}
+	if out.ext == "jpg" {
+		args = append(args, "-jpeg", "-jpegopt", "quality=85")
+	} else {
+		args = append(args, "-png")
+	}
+	if width > 0 {
+		args = append(args, "-scale-to-x", strconv.Itoa(width), "-scale-to-y", "-1")
+	} else {
+		args = append(args, "-r", strconv.Itoa(dpi))
+	}
+
+	result := make([]*docgenpb.PreviewPage, 0, len(pages))
+	for _, p := range pages {
+		n := strconv.Itoa(p)
+		prefix := filepath.Join(dir, "p"+n)
+		cmd := exec.CommandContext(ctx, bin, append(args, "-f", n, "-l", n, in, prefix)...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("pdftoppm page %d: %v, stderr: %s", p, err, stderr.String())
+		}
+		// -singlefile: tanpa nomor halaman di nama file; ekstensi jpeg = .jpg
+		img, err := os.ReadFile(prefix + "." + out.ext)
+		if err != nil {
+			return nil, err
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(img))
+		if err != nil {
+			return nil, fmt.Errorf("pdftoppm page %d: %v", p, err)
+		}
+		result = append(result, &docgenpb.PreviewPage{
+			Page:        int32(p),
+			Image:       img,
+			ContentType: out.contentType,
+			Width:       int32(cfg.Width),
+			Height:      int32(cfg.Height),
+		})
+	}
+	return result, nil
+}
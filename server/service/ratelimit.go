@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ClientLimits are the limits of one client; zero disables a limit.
+type ClientLimits struct {
+	Rate             float64 // requests per second
+	Burst            int
+	DailyDocuments   int // successful generate/merge calls per UTC day
+	MonthlyDocuments int // ... per UTC month
+}
+
+// LimiterConfig configures a ClientLimiter.
+type LimiterConfig struct {
+	// KeyBy selects what a client is: "caller" (the authenticated identity,
+	// e.g. "key:<id>"), "tenant" ("tenant:<name>", the caller without a
+	// tenant) or "ip" ("ip:<address>"). Calls without a caller are keyed by
+	// IP.
+	KeyBy   string
+	Default ClientLimits
+	// Clients overrides Default for single clients, by key. An entry
+	// "tenant:<name>" also applies to every client of that tenant that has no
+	// entry of its own; each client still gets its own bucket and counters.
+	Clients map[string]ClientLimits
+	// Usage keeps the quota counters; nil keeps them in memory.
+	Usage UsageStore
+}
+
+// documentMethods are the calls that count against document quotas.
+var documentMethods = map[string]bool{
+	docgenpb.DocService_GeneratePDF_FullMethodName:    true,
+	docgenpb.DocService_GenerateDocx_FullMethodName:   true,
+	docgenpb.DocService_Generate_FullMethodName:       true,
+	docgenpb.DocService_MergeDocuments_FullMethodName: true,
+}
+
+// idleLimiter is how long an unused rate limiter is kept; after that it
+// would be full again anyway.
+const idleLimiter = 10 * time.Minute
+
+// ClientLimiter rate limits every client separately and enforces document
+// quotas. It reports what is left in response headers:
+//
+//	x-ratelimit-limit, x-ratelimit-remaining, retry-after (when limited)
+//	x-quota-daily-limit, x-quota-daily-remaining
+//	x-quota-monthly-limit, x-quota-monthly-remaining
+type ClientLimiter struct {
+	cfg LimiterConfig
+
+	mu       sync.Mutex
+	limiters map[string]*clientBucket
+	swept    time.Time
+}
+
+type clientBucket struct {
+	lim  *rate.Limiter
+	used time.Time
+}
+
+func NewClientLimiter(cfg LimiterConfig) (*ClientLimiter, error) {
+	switch cfg.KeyBy {
+	case "":
+		cfg.KeyBy = "caller"
+	case "caller", "tenant", "ip":
+	default:
+		return nil, fmt.Errorf("unknown rate limit key %q (want caller, tenant or ip)", cfg.KeyBy)
+	}
+	if cfg.Usage == nil {
+		cfg.Usage = NewMemoryUsageStore()
+	}
+	return &ClientLimiter{cfg: cfg, limiters: map[string]*clientBucket{}}, nil
+}
+
+// clientKey returns the key of the client making the call.
+func (l *ClientLimiter) clientKey(ctx context.Context) string {
+	p, ok := PrincipalFromContext(ctx)
+	switch {
+	case l.cfg.KeyBy == "tenant" && ok && p.Tenant != "":
+		return "tenant:" + p.Tenant
+	case l.cfg.KeyBy != "ip" && ok:
+		return p.ID
+	}
+	ip := "unknown"
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		ip = pr.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	return "ip:" + ip
+}
+
+// limits returns the limits of key; a client without its own falls back to
+// those of its tenant ("tenant:<name>"), then to Default.
+func (l *ClientLimiter) limits(ctx context.Context, key string) ClientLimits {
+	if c, ok := l.cfg.Clients[key]; ok {
+		return c
+	}
+	if t := TenantFromContext(ctx); t != "" {
+		if c, ok := l.cfg.Clients["tenant:"+t]; ok {
+			return c
+		}
+	}
+	return l.cfg.Default
+}
+
+// allow takes a token from the client's bucket. It returns the header
+// metadata to send and, when limited, a ResourceExhausted error.
+func (l *ClientLimiter) allow(ctx context.Context, key string, lim ClientLimits) (metadata.MD, error) {
+	if lim.Rate <= 0 {
+		return nil, nil
+	}
+	now := time.Now()
+	l.mu.Lock()
+	if now.Sub(l.swept) > time.Minute {
+		for k, b := range l.limiters {
+			if now.Sub(b.used) > idleLimiter {
+				delete(l.limiters, k)
+			}
+		}
+		l.swept = now
+	}
+	b := l.limiters[key]
+	if b == nil || b.lim.Limit() != rate.Limit(lim.Rate) || b.lim.Burst() != max(lim.Burst, 1) {
+		b = &clientBucket{lim: rate.NewLimiter(rate.Limit(lim.Rate), max(lim.Burst, 1))}
+		l.limiters[key] = b
+	}
+	b.used = now
+	r := b.lim.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	if delay > 0 {
+		r.CancelAt(now)
+	}
+	remaining := int(math.Max(0, b.lim.TokensAt(now)))
+	l.mu.Unlock()
+
+	md := metadata.Pairs(
+		"x-ratelimit-limit", strconv.FormatFloat(lim.Rate, 'f', -1, 64),
+		"x-ratelimit-remaining", strconv.Itoa(remaining),
+	)
+	if delay > 0 {
+		md.Set("retry-after", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		limitedRequests.WithLabelValues(TenantFromContext(ctx), "rate").Inc()
+		return md, status.Errorf(codes.ResourceExhausted, "rate limit of %g requests per second exceeded, retry in %s", lim.Rate, delay.Round(time.Millisecond))
+	}
+	return md, nil
+}
+
+// UnaryInterceptor applies the client's rate limit. Place it after the auth
+// interceptor so calls are keyed by caller.
+func (l *ClientLimiter) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	key := l.clientKey(ctx)
+	md, err := l.allow(ctx, key, l.limits(ctx, key))
+	if md != nil {
+		_ = grpc.SetHeader(ctx, md)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor applies the client's rate limit to every stream opened.
+func (l *ClientLimiter) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	key := l.clientKey(ctx)
+	md, err := l.allow(ctx, key, l.limits(ctx, key))
+	if md != nil {
+		_ = ss.SetHeader(md)
+	}
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// QuotaInterceptor counts successful document calls against the daily and
+// monthly quotas and rejects calls once one is used up. Place it after the
+// idempotency interceptor so that replayed responses are not counted again.
+func (l *ClientLimiter) QuotaInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !documentMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	key := l.clientKey(ctx)
+	lim := l.limits(ctx, key)
+	if lim.DailyDocuments <= 0 && lim.MonthlyDocuments <= 0 {
+		return handler(ctx, req)
+	}
+	// reservasi dulu supaya request paralel tidak melewati kuota
+	now := time.Now()
+	u, err := l.cfg.Usage.Add(key, 1, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "count usage: %v", err)
+	}
+	var period string
+	var quota int
+	switch {
+	case lim.DailyDocuments > 0 && u.DayCount > lim.DailyDocuments:
+		period, quota = "daily", lim.DailyDocuments
+	case lim.MonthlyDocuments > 0 && u.MonthCount > lim.MonthlyDocuments:
+		period, quota = "monthly", lim.MonthlyDocuments
+	}
+	if period != "" {
+		u = l.release(key, now)
+		_ = grpc.SetHeader(ctx, quotaHeaders(lim, u))
+		limitedRequests.WithLabelValues(TenantFromContext(ctx), period+"_quota").Inc()
+		return nil, status.Errorf(codes.ResourceExhausted, "%s document quota of %d exhausted", period, quota)
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		u = l.release(key, now)
+	}
+	_ = grpc.SetHeader(ctx, quotaHeaders(lim, u))
+	return resp, err
+}
+
+// release gives back a reservation QuotaInterceptor made at time at. The
+// handler may have run past midnight, so the counters are rolled to the
+// current time first.
+func (l *ClientLimiter) release(key string, at time.Time) Usage {
+	u, err := l.cfg.Usage.Release(key, at, time.Now())
+	if err != nil {
+		logger.Error("release document quota failed", zap.String("client", key), zap.Error(err))
+	}
+	return u
+}
+
+func quotaHeaders(lim ClientLimits, u Usage) metadata.MD {
+	md := metadata.MD{}
+	if lim.DailyDocuments > 0 {
+		md.Set("x-quota-daily-limit", strconv.Itoa(lim.DailyDocuments))
+		md.Set("x-quota-daily-remaining", strconv.Itoa(max(lim.DailyDocuments-u.DayCount, 0)))
+	}
+	if lim.MonthlyDocuments > 0 {
+		md.Set("x-quota-monthly-limit", strconv.Itoa(lim.MonthlyDocuments))
+		md.Set("x-quota-monthly-remaining", strconv.Itoa(max(lim.MonthlyDocuments-u.MonthCount, 0)))
+	}
+	return md
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func callerCtx(id, tenant string) context.Context {
+	return withPrincipal(context.Background(), &Principal{ID: id, Tenant: tenant})
+}
+
+func okHandler(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+func TestClientLimiterRate(t *testing.T) {
+	l, err := NewClientLimiter(LimiterConfig{
+		Default: ClientLimits{Rate: 0.001, Burst: 2},
+		Clients: map[string]ClientLimits{
+			"key:batch":   {Rate: 0.001, Burst: 4},
+			"tenant:acme": {Rate: 0.001, Burst: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GetPlaceholders_FullMethodName}
+	allowed := func(ctx context.Context) int {
+		n := 0
+		for i := 0; i < 10; i++ {
+			_, err := l.UnaryInterceptor(ctx, nil, info, okHandler)
+			switch status.Code(err) {
+			case codes.OK:
+				n++
+			case codes.ResourceExhausted:
+			default:
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+		return n
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int
+	}{
+		{"default", callerCtx("key:a", ""), 2},
+		{"own bucket per caller", callerCtx("key:b", ""), 2},
+		{"client override", callerCtx("key:batch", ""), 4},
+		{"tenant override", callerCtx("key:c", "acme"), 1},
+		{"tenant override, other client", callerCtx("key:d", "acme"), 1},
+	}
+	for _, tt := range tests {
+		if got := allowed(tt.ctx); got != tt.want {
+			t.Errorf("%s: %d calls allowed, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	md, err := l.allow(context.Background(), "key:a", l.cfg.Default)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("err = %v", err)
+	}
+	if md.Get("retry-after") == nil || md.Get("x-ratelimit-remaining")[0] != "0" || md.Get("x-ratelimit-limit")[0] != "0.001" {
+		t.Errorf("headers = %v", md)
+	}
+}
+
+func TestClientLimiterKeyBy(t *testing.T) {
+	if _, err := NewClientLimiter(LimiterConfig{KeyBy: "user"}); err == nil {
+		t.Fatal("accepted an unknown key")
+	}
+	tests := []struct {
+		keyBy string
+		ctx   context.Context
+		want  string
+	}{
+		{"caller", callerCtx("key:a", "acme"), "key:a"},
+		{"tenant", callerCtx("key:a", "acme"), "tenant:acme"},
+		{"tenant", callerCtx("key:a", ""), "key:a"},
+		{"ip", callerCtx("key:a", "acme"), "ip:unknown"},
+		{"caller", context.Background(), "ip:unknown"},
+	}
+	for _, tt := range tests {
+		l, err := NewClientLimiter(LimiterConfig{KeyBy: tt.keyBy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := l.clientKey(tt.ctx); got != tt.want {
+			t.Errorf("%s: clientKey = %q, want %q", tt.keyBy, got, tt.want)
+		}
+	}
+}
+
+func TestQuotaInterceptor(t *testing.T) {
+	usage := NewMemoryUsageStore()
+	l, err := NewClientLimiter(LimiterConfig{Default: ClientLimits{DailyDocuments: 2}, Usage: usage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := callerCtx("key:a", "")
+	generate := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GeneratePDF_FullMethodName}
+	count := func() int { return usage.Get("key:a", time.Now()).DayCount }
+
+	// panggilan non-dokumen tidak dihitung
+	for i := 0; i < 3; i++ {
+		if _, err := l.QuotaInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_VerifyPDF_FullMethodName}, okHandler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("non-document calls counted: %d", n)
+	}
+
+	// failed calls give back their reservation
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad template")
+	}
+	if _, err := l.QuotaInterceptor(ctx, nil, generate, failing); status.Code(err) != codes.InvalidArgument {
+		t.Fatal(err)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("failed call counted: %d", n)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.QuotaInterceptor(ctx, nil, generate, okHandler); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	ran := false
+	_, err = l.QuotaInterceptor(ctx, nil, generate, func(ctx context.Context, req interface{}) (interface{}, error) {
+		ran = true
+		return "ok", nil
+	})
+	if status.Code(err) != codes.ResourceExhausted || ran {
+		t.Fatalf("over quota: err = %v, handler ran = %v", err, ran)
+	}
+	if n := count(); n != 2 {
+		t.Errorf("rejected call left its reservation: count = %d", n)
+	}
+	if _, err := l.QuotaInterceptor(callerCtx("key:b", ""), nil, generate, okHandler); err != nil {
+		t.Errorf("other client: %v", err)
+	}
+}
+
+func TestQuotaInterceptorConcurrentReservations(t *testing.T) {
+	l, err := NewClientLimiter(LimiterConfig{Default: ClientLimits{MonthlyDocuments: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := callerCtx("key:a", "")
+	generate := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_MergeDocuments_FullMethodName}
+
+	// semua handler ditahan sampai semua request masuk, jadi hanya
+	// reservasi yang bisa membatasi
+	release := make(chan struct{})
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-release
+		return "ok", nil
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ok, exhausted int
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := l.QuotaInterceptor(ctx, nil, generate, slow)
+			mu.Lock()
+			defer mu.Unlock()
+			switch status.Code(err) {
+			case codes.OK:
+				ok++
+			case codes.ResourceExhausted:
+				exhausted++
+			}
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := exhausted
+		mu.Unlock()
+		if n == 7 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if ok != 3 || exhausted != 7 {
+		t.Errorf("ok = %d, exhausted = %d; want 3 and 7", ok, exhausted)
+	}
+	if u := l.cfg.Usage.Get("key:a", time.Now()); u.MonthCount != 3 {
+		t.Errorf("month count = %d, want 3", u.MonthCount)
+	}
+}
+
+func TestQuotaHeaders(t *testing.T) {
+	md := quotaHeaders(ClientLimits{DailyDocuments: 10, MonthlyDocuments: 100}, Usage{DayCount: 12, MonthCount: 40})
+	want := map[string]string{
+		"x-quota-daily-limit":       "10",
+		"x-quota-daily-remaining":   "0",
+		"x-quota-monthly-limit":     "100",
+		"x-quota-monthly-remaining": "60",
+	}
+	for k, v := range want {
+		if got := md.Get(k); len(got) != 1 || got[0] != v {
+			t.Errorf("%s = %v, want %s", k, got, v)
+		}
+	}
+	if md := quotaHeaders(ClientLimits{DailyDocuments: 1}, Usage{}); md.Get("x-quota-monthly-limit") != nil {
+		t.Errorf("monthly header without a monthly quota: %v", md)
+	}
+}
+
+func TestUsageStoreRollsOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	s, err := OpenFileUsageStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jan31 := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, err := s.Add("key:a", 1, jan31); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Release("key:a", jan31, jan31); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenFileUsageStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := reopened.Get("key:a", jan31); u.DayCount != 2 || u.MonthCount != 2 {
+		t.Errorf("reopened usage = %+v", u)
+	}
+	// 1 Februari 00:30 WIB masih 31 Januari UTC
+	wib := time.FixedZone("WIB", 7*3600)
+	if u := reopened.Get("key:a", time.Date(2026, 2, 1, 0, 30, 0, 0, wib)); u.DayCount != 2 {
+		t.Errorf("usage in local time = %+v", u)
+	}
+	feb1 := jan31.Add(2 * time.Hour)
+	if u := reopened.Get("key:a", feb1); u.DayCount != 0 || u.MonthCount != 0 {
+		t.Errorf("usage next month = %+v", u)
+	}
+	if _, err := reopened.Add("key:a", 1, feb1); err != nil {
+		t.Fatal(err)
+	}
+	if u := reopened.Get("key:a", feb1.AddDate(0, 0, 1)); u.DayCount != 0 || u.MonthCount != 1 {
+		t.Errorf("usage next day = %+v", u)
+	}
+}
+
+func TestUsageReleaseAcrossMidnight(t *testing.T) {
+	file, err := OpenFileUsageStore(filepath.Join(t.TempDir(), "usage.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, s := range map[string]UsageStore{"memory": NewMemoryUsageStore(), "file": file} {
+		t.Run(name, func(t *testing.T) {
+			beforeMidnight := time.Date(2026, 1, 31, 23, 59, 50, 0, time.UTC)
+			afterMidnight := time.Date(2026, 2, 1, 0, 0, 10, 0, time.UTC)
+			// konversi panjang mulai sebelum tengah malam ...
+			if _, err := s.Add("key:a", 1, beforeMidnight); err != nil {
+				t.Fatal(err)
+			}
+			// ... panggilan lain sudah masuk hari (dan bulan) baru ...
+			if _, err := s.Add("key:a", 1, afterMidnight); err != nil {
+				t.Fatal(err)
+			}
+			// ... lalu yang pertama gagal dan reservasinya dikembalikan
+			u, err := s.Release("key:a", beforeMidnight, afterMidnight.Add(time.Second))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Day != "2026-02-01" || u.DayCount != 1 || u.Month != "2026-02" || u.MonthCount != 1 {
+				t.Fatalf("usage after release = %+v", u)
+			}
+			if u := s.Get("key:a", afterMidnight.Add(time.Minute)); u.DayCount != 1 || u.MonthCount != 1 {
+				t.Errorf("usage later that day = %+v", u)
+			}
+
+			// hari baru, bulan sama: hanya hitungan bulan yang dikembalikan
+			feb2 := afterMidnight.AddDate(0, 0, 1)
+			if _, err := s.Add("key:a", 1, feb2); err != nil {
+				t.Fatal(err)
+			}
+			if u, _ := s.Release("key:a", afterMidnight, feb2); u.DayCount != 1 || u.MonthCount != 1 {
+				t.Errorf("usage after release from the previous day = %+v", u)
+			}
+			if u, _ := s.Release("key:a", feb2, feb2); u.DayCount != 0 || u.MonthCount != 0 {
+				t.Errorf("usage after same-day release = %+v", u)
+			}
+		})
+	}
+}
+
+// clockedUsage records the times Release is called with.
+type clockedUsage struct {
+	*MemoryUsageStore
+	added, at, now time.Time
+}
+
+func (s *clockedUsage) Add(key string, n int, now time.Time) (Usage, error) {
+	s.added = now
+	return s.MemoryUsageStore.Add(key, n, now)
+}
+
+func (s *clockedUsage) Release(key string, at, now time.Time) (Usage, error) {
+	s.at, s.now = at, now
+	return s.MemoryUsageStore.Release(key, at, now)
+}
+
+func TestQuotaReleaseUsesReservationTime(t *testing.T) {
+	usage := &clockedUsage{MemoryUsageStore: NewMemoryUsageStore()}
+	l, err := NewClientLimiter(LimiterConfig{Default: ClientLimits{DailyDocuments: 5}, Usage: usage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	generate := &grpc.UnaryServerInfo{FullMethod: docgenpb.DocService_GeneratePDF_FullMethodName}
+	slowFailure := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, status.Error(codes.Internal, "conversion failed")
+	}
+	if _, err := l.QuotaInterceptor(callerCtx("key:a", ""), nil, generate, slowFailure); status.Code(err) != codes.Internal {
+		t.Fatal(err)
+	}
+	if !usage.at.Equal(usage.added) {
+		t.Errorf("released reservation of %v, made at %v", usage.at, usage.added)
+	}
+	if usage.now.Sub(usage.added) < 20*time.Millisecond {
+		t.Errorf("release rolled counters at %v, before the handler finished", usage.now)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DocumentRecord is what the service remembers about a document it produced.
+type DocumentRecord struct {
+	SHA256      string    `json:"sha256"` // hex, of the exact bytes returned
+	Size        int       `json:"size"`
+	ContentType string    `json:"content_type"`
+	Filename    string    `json:"filename"`
+	Signer      string    `json:"signer,omitempty"` // signer profile, if signed
+	Code        string    `json:"code,omitempty"`   // verification code, if stamped
+	Caller      string    `json:"caller,omitempty"`
+	Tenant      string    `json:"tenant,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DocumentRegistry records the hash of every generated document so a copy
+// sent back later can be recognised, by its bytes or by its verification code.
+// Tenants are recorded separately: identical bytes produced by two tenants
+// give two records.
+type DocumentRegistry interface {
+	Record(rec DocumentRecord) error
+	// Lookup returns the records of a hash, at most one per tenant, oldest
+	// first.
+	Lookup(sha256 string) []DocumentRecord
+	LookupCode(code string) (DocumentRecord, bool)
+}
+
+// WithRegistry records every generate and merge result in r.
+func WithRegistry(r DocumentRegistry) Option {
+	return func(s *DocService) { s.registry = r }
+}
+
+// record stores resp in the registry. A document that cannot be recorded
+// could not be verified later, so the call fails instead.
+func (s *DocService) record(ctx context.Context, resp *docgenpb.GenerateResponse, signer string) error {
+	if s.registry == nil {
+		return nil
+	}
+	sum := sha256.Sum256(resp.GetContent())
+	err := s.registry.Record(DocumentRecord{
+		SHA256:      hex.EncodeToString(sum[:]),
+		Size:        len(resp.GetContent()),
+		ContentType: resp.GetContentType(),
+		Filename:    resp.GetFilename(),
+		Signer:      signer,
+		Code:        resp.GetDocumentCode(),
+		Caller:      CallerFromContext(ctx),
+		Tenant:      TenantFromContext(ctx),
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "record document: %v", err)
+	}
+	return nil
+}
+
+// ---------- in-memory ----------
+
+// MemoryRegistry keeps records for the life of the process.
+type MemoryRegistry struct {
+	mu      sync.RWMutex
+	records map[string][]DocumentRecord // sha256 -> one record per tenant
+	codes   map[string]DocumentRecord
+}
+
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{records: map[string][]DocumentRecord{}, codes: map[string]DocumentRecord{}}
+}
+
+// Record keeps the first record for a hash and tenant; the same bytes
+// produced again (e.g. a cache hit) do not move the original creation time.
+func (r *MemoryRegistry) Record(rec DocumentRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.has(rec) {
+		return nil
+	}
+	r.records[rec.SHA256] = append(r.records[rec.SHA256], rec)
+	if rec.Code != "" {
+		r.codes[rec.Code] = rec
+	}
+	return nil
+}
+
+// has reports whether rec's hash is recorded for its tenant; r.mu must be held.
+func (r *MemoryRegistry) has(rec DocumentRecord) bool {
+	for _, old := range r.records[rec.SHA256] {
+		if old.Tenant == rec.Tenant {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryRegistry) Lookup(sha256 string) []DocumentRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]DocumentRecord(nil), r.records[sha256]...)
+}
+
+func (r *MemoryRegistry) LookupCode(code string) (DocumentRecord, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rec, ok := r.codes[code]
+	return rec, ok
+}
+
+// ---------- file ----------
+
+// FileRegistry appends records as JSON lines to a file and keeps an index in
+// memory, rebuilt from the file on open.
+type FileRegistry struct {
+	mem *MemoryRegistry
+	mu  sync.Mutex // serialises appends
+	f   *os.File
+}
+
+func OpenFileRegistry(path string) (*FileRegistry, error) {
+	r := &FileRegistry{mem: NewMemoryRegistry()}
+	if f, err := os.Open(path); err == nil {
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64<<10), 1<<20)
+		for line := 1; sc.Scan(); line++ {
+			var rec DocumentRecord
+			if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			_ = r.mem.Record(rec)
+		}
+		f.Close()
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	r.f = f
+	return r, nil
+}
+
+func (r *FileRegistry) Record(rec DocumentRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mem.mu.RLock()
+	dup := r.mem.has(rec)
+	r.mem.mu.RUnlock()
+	if dup {
+		return nil
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := r.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return r.mem.Record(rec)
+}
+
+func (r *FileRegistry) Lookup(sha256 string) []DocumentRecord {
+	return r.mem.Lookup(sha256)
+}
+
+func (r *FileRegistry) LookupCode(code string) (DocumentRecord, bool) {
+	return r.mem.LookupCode(code)
+}
+
+func (r *FileRegistry) Close() error {
+	return r.f.Close()
+}
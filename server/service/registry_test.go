@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryKeepsOneRecordPerTenant(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.jsonl")
+	reg, err := OpenFileRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range []DocumentRecord{
+		{SHA256: "aa", Tenant: "acme", Code: "CODE-A"},
+		{SHA256: "aa", Tenant: "beta", Code: "CODE-B"},
+		{SHA256: "aa", Tenant: "acme", Code: "CODE-C"}, // duplikat, diabaikan
+	} {
+		if err := reg.Record(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reg.Close()
+
+	reg, err = OpenFileRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reg.Close()
+	if recs := reg.Lookup("aa"); len(recs) != 2 || recs[0].Tenant != "acme" || recs[1].Tenant != "beta" {
+		t.Fatalf("Lookup = %+v, want acme then beta", recs)
+	}
+	for code, tenant := range map[string]string{"CODE-A": "acme", "CODE-B": "beta"} {
+		if rec, ok := reg.LookupCode(code); !ok || rec.Tenant != tenant {
+			t.Errorf("LookupCode(%s) = %+v, %v; want tenant %s", code, rec, ok, tenant)
+		}
+	}
+	if _, ok := reg.LookupCode("CODE-C"); ok {
+		t.Error("duplicate record registered its code")
+	}
+
+	s := NewDocService(nil, WithRegistry(reg))
+	beta := withPrincipal(context.Background(), &Principal{ID: "key:b", Tenant: "beta"})
+	if rec, ok := s.lookup(beta, "aa"); !ok || rec.Tenant != "beta" {
+		t.Errorf("lookup as beta = %+v, %v", rec, ok)
+	}
+	other := withPrincipal(context.Background(), &Principal{ID: "key:c", Tenant: "gamma"})
+	if _, ok := s.lookup(other, "aa"); ok {
+		t.Error("gamma sees documents of other tenants")
+	}
+}
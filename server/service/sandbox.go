@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SandboxConfig limits every LibreOffice process. Zero values disable a limit.
+type SandboxConfig struct {
+	CPUTime      time.Duration // RLIMIT_CPU
+	WallTime     time.Duration // process group is killed after this
+	AddressSpace int64         // RLIMIT_AS, bytes
+	OpenFiles    uint64        // RLIMIT_NOFILE
+	FileSize     int64         // RLIMIT_FSIZE, bytes: largest file soffice may write
+	// Namespaces runs soffice without network and with a private /tmp
+	// (Linux user namespaces); falls back to rlimits only when the kernel or
+	// seccomp profile does not allow them.
+	Namespaces bool
+}
+
+// DefaultSandbox leaves room for large documents; LibreOffice reserves a lot
+// of address space it never touches.
+var DefaultSandbox = SandboxConfig{
+	CPUTime:      2 * time.Minute,
+	WallTime:     5 * time.Minute,
+	AddressSpace: 4 << 30,
+	OpenFiles:    1024,
+	FileSize:     512 << 20,
+	Namespaces:   true,
+}
+
+var (
+	sandboxCfg atomic.Pointer[SandboxConfig]
+	// namespacesOK turns false after the first refused attempt
+	namespacesOK atomic.Bool
+)
+
+func init() {
+	cfg := DefaultSandbox
+	sandboxCfg.Store(&cfg)
+	namespacesOK.Store(true)
+}
+
+// ConfigureSandbox replaces DefaultSandbox for conversions started afterwards.
+func ConfigureSandbox(cfg SandboxConfig) {
+	sandboxCfg.Store(&cfg)
+}
+
+// sandboxJob is the private directory of one conversion:
+//
+//	in/   the input document
+//	out/  --outdir
+//	home/ HOME and the LibreOffice profile (-env:UserInstallation)
+//	tmp/  TMPDIR
+//	fonts.conf  fontconfig with the tenant's font dirs, when there are any
+//
+// With namespaces the directory is mounted on /tmp inside the sandbox, so the
+// process sees nothing of the host's /tmp. Paths given to soffice are
+// relative to it.
+type sandboxJob struct {
+	dir   string
+	input string // relative
+	fonts bool   // fonts.conf written
+}
+
+func newSandboxJob(inputPath string, fontDirs []string) (*sandboxJob, error) {
+	dir, err := os.MkdirTemp("", "soffice-*")
+	if err != nil {
+		return nil, err
+	}
+	j := &sandboxJob{dir: dir, input: filepath.Join("in", filepath.Base(inputPath))}
+	for _, sub := range []string{"in", "out", "home", "tmp"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o700); err != nil {
+			j.remove()
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(inputPath)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, j.input), data, 0o600)
+	}
+	if err == nil && len(fontDirs) > 0 {
+		err = j.writeFontConfig(fontDirs)
+	}
+	if err != nil {
+		j.remove()
+		return nil, err
+	}
+	return j, nil
+}
+
+// writeFontConfig writes a fontconfig file that adds fontDirs to the system
+// configuration. The font cache goes to tmp/ so tenants never share it.
+func (j *sandboxJob) writeFontConfig(fontDirs []string) error {
+	system := os.Getenv("FONTCONFIG_FILE")
+	if system == "" {
+		system = "/etc/fonts/fonts.conf"
+	}
+	var b bytes.Buffer
+	b.WriteString("<?xml version=\"1.0\"?>\n<!DOCTYPE fontconfig SYSTEM \"fonts.dtd\">\n<fontconfig>\n")
+	fmt.Fprintf(&b, "  <include ignore_missing=\"yes\">%s</include>\n", xmlEscape(system))
+	for _, d := range fontDirs {
+		fmt.Fprintf(&b, "  <dir>%s</dir>\n", xmlEscape(d))
+	}
+	b.WriteString("  <cachedir prefix=\"relative\">tmp/fontconfig</cachedir>\n</fontconfig>\n")
+	j.fonts = true
+	return os.WriteFile(filepath.Join(j.dir, "fonts.conf"), b.Bytes(), 0o600)
+}
+
+func (j *sandboxJob) remove() { os.RemoveAll(j.dir) }
+
+// run executes soffice with args inside the sandbox.
+func (j *sandboxJob) run(soffice string, args ...string) error {
+	cfg := *sandboxCfg.Load()
+	ns := cfg.Namespaces && namespacesSupported && namespacesOK.Load()
+	for {
+		err := j.runOnce(soffice, args, cfg, ns)
+		if ns && isNamespaceRefused(err) {
+			if namespacesOK.CompareAndSwap(true, false) {
+				logger.Warn("linux namespaces unavailable, sandboxing LibreOffice with rlimits only", zap.Error(err))
+			}
+			ns = false
+			continue
+		}
+		return err
+	}
+}
+
+func (j *sandboxJob) runOnce(soffice string, args []string, cfg SandboxConfig, ns bool) error {
+	root := j.dir
+	if ns {
+		root = "/tmp"
+	}
+	profile := url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(root, "home", "profile"))}
+	args = append([]string{"-env:UserInstallation=" + profile.String()}, args...)
+
+	cmd := sandboxCommand(soffice, args, cfg, ns, j.dir)
+	cmd.Dir = j.dir
+	cmd.Env = append(cmd.Env, sandboxEnv(root)...)
+	if j.fonts {
+		// variabel terakhir yang dipakai, jadi ini menimpa FONTCONFIG_FILE host
+		cmd.Env = append(cmd.Env, "FONTCONFIG_FILE="+filepath.Join(root, "fonts.conf"))
+	}
+	var stderr bytes.Buffer
+	cmd.Stdout = &stderr
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return &startError{err}
+	}
+
+	var timedOut atomic.Bool
+	if cfg.WallTime > 0 {
+		t := time.AfterFunc(cfg.WallTime, func() {
+			timedOut.Store(true)
+			killProcessGroup(cmd)
+		})
+		defer t.Stop()
+	}
+	err := cmd.Wait()
+	// soffice.bin bisa tertinggal sebagai anak oosplash
+	killProcessGroup(cmd)
+	if err == nil {
+		return nil
+	}
+	if timedOut.Load() {
+		return status.Errorf(codes.DeadlineExceeded, "libreoffice convert: killed after %v wall time limit", cfg.WallTime)
+	}
+	if msg := limitExceeded(cmd.ProcessState, cfg); msg != "" {
+		return status.Errorf(codes.ResourceExhausted, "libreoffice convert: %s, output: %s", msg, stderr.String())
+	}
+	return fmt.Errorf("libreoffice convert: %v, stderr: %s", err, stderr.String())
+}
+
+type startError struct{ err error }
+
+func (e *startError) Error() string { return "start libreoffice: " + e.err.Error() }
+func (e *startError) Unwrap() error { return e.err }
+
+// sandboxEnv is the whole environment of soffice: nothing of the service's
+// own environment (API keys, key passwords) is passed on.
+func sandboxEnv(root string) []string {
+	env := []string{
+		"PATH=/usr/local/bin:/usr/bin:/bin",
+		"HOME=" + filepath.Join(root, "home"),
+		"TMPDIR=" + filepath.Join(root, "tmp"),
+		"SAL_USE_VCLPLUGIN=svp",
+	}
+	lang := "C.UTF-8"
+	if v := os.Getenv("LANG"); v != "" {
+		lang = v
+	}
+	env = append(env, "LANG="+lang)
+	for _, k := range []string{"LC_ALL", "FONTCONFIG_FILE", "FONTCONFIG_PATH", "TZ"} {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+	return env
+}
+
+func byteSize(n int64) string {
+	switch {
+	case n >= 1<<30 && n%(1<<30) == 0:
+		return fmt.Sprintf("%d GiB", n>>30)
+	case n >= 1<<20:
+		return fmt.Sprintf("%d MiB", n>>20)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
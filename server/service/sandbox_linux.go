@@ -0,0 +1,159 @@
+//go:build linux
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const namespacesSupported = true
+
+// The service binary re-executes itself (/proc/self/exe) with this argument
+// to set up the sandbox in the child and then exec soffice; Go cannot set
+// rlimits or mounts between fork and exec on its own.
+const sandboxExecArg = "__docgen-sandbox-exec"
+
+const (
+	envSandboxLimits = "DOCGEN_SANDBOX_LIMITS" // "cpu=120,as=4294967296,nofile=1024,fsize=536870912"
+	envSandboxBind   = "DOCGEN_SANDBOX_BIND"   // job dir to mount on /tmp
+)
+
+func init() {
+	if len(os.Args) > 2 && os.Args[1] == sandboxExecArg {
+		sandboxExec(os.Args[2:])
+	}
+}
+
+func sandboxCommand(bin string, args []string, cfg SandboxConfig, ns bool, dir string) *exec.Cmd {
+	cmd := exec.Command("/proc/self/exe", append([]string{sandboxExecArg, bin}, args...)...)
+	limits := []string{}
+	if cfg.CPUTime > 0 {
+		limits = append(limits, "cpu="+strconv.FormatInt(int64(cfg.CPUTime.Seconds()+0.5), 10))
+	}
+	if cfg.AddressSpace > 0 {
+		limits = append(limits, "as="+strconv.FormatInt(cfg.AddressSpace, 10))
+	}
+	if cfg.OpenFiles > 0 {
+		limits = append(limits, "nofile="+strconv.FormatUint(cfg.OpenFiles, 10))
+	}
+	if cfg.FileSize > 0 {
+		limits = append(limits, "fsize="+strconv.FormatInt(cfg.FileSize, 10))
+	}
+	cmd.Env = []string{envSandboxLimits + "=" + strings.Join(limits, ",")}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pdeathsig: syscall.SIGKILL}
+	if ns {
+		cmd.Env = append(cmd.Env, envSandboxBind+"="+dir)
+		// root di dalam user namespace (hanya untuk mount), di luar tetap uid service
+		cmd.SysProcAttr.Cloneflags = syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET | syscall.CLONE_NEWIPC
+		cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+		cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	}
+	return cmd
+}
+
+// sandboxExec runs in the re-executed child: private /tmp, rlimits, then
+// exec of the real program. It never returns.
+func sandboxExec(args []string) {
+	fail := func(format string, a ...interface{}) {
+		fmt.Fprintf(os.Stderr, "sandbox: "+format+"\n", a...)
+		os.Exit(125)
+	}
+	if dir := os.Getenv(envSandboxBind); dir != "" {
+		if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+			fail("make mounts private: %v", err)
+		}
+		if err := syscall.Mount(dir, "/tmp", "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			fail("mount %s on /tmp: %v", dir, err)
+		}
+		if err := os.Chdir("/tmp"); err != nil {
+			fail("%v", err)
+		}
+	}
+	for _, kv := range strings.Split(os.Getenv(envSandboxLimits), ",") {
+		name, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			fail("limit %s: %v", kv, err)
+		}
+		res := map[string]int{"cpu": syscall.RLIMIT_CPU, "as": syscall.RLIMIT_AS, "nofile": syscall.RLIMIT_NOFILE, "fsize": syscall.RLIMIT_FSIZE}[name]
+		if name == "cpu" {
+			// soft limit kirim SIGXCPU, hard limit 5 detik kemudian SIGKILL
+			err = syscall.Setrlimit(res, &syscall.Rlimit{Cur: n, Max: n + 5})
+		} else {
+			err = syscall.Setrlimit(res, &syscall.Rlimit{Cur: n, Max: n})
+		}
+		if err != nil {
+			fail("set %s limit: %v", name, err)
+		}
+	}
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, envSandboxLimits+"=") && !strings.HasPrefix(kv, envSandboxBind+"=") {
+			env = append(env, kv)
+		}
+	}
+	err := syscall.Exec(args[0], args, env)
+	fail("exec %s: %v", args[0], err)
+}
+
+// isNamespaceRefused reports whether starting the child failed because user
+// namespaces are not allowed (sysctl, seccomp in containers, limits).
+func isNamespaceRefused(err error) bool {
+	var se *startError
+	if !errors.As(err, &se) {
+		return false
+	}
+	return errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) ||
+		errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EACCES)
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// limitExceeded explains an exit caused by one of the rlimits or the OOM
+// killer. oosplash reports a crashed soffice.bin as exit code 128+signal.
+func limitExceeded(ps *os.ProcessState, cfg SandboxConfig) string {
+	ws, ok := ps.Sys().(syscall.WaitStatus)
+	if !ok {
+		return ""
+	}
+	sig := syscall.Signal(-1)
+	switch {
+	case ws.Signaled():
+		sig = ws.Signal()
+	case ws.Exited() && ws.ExitStatus() > 128:
+		sig = syscall.Signal(ws.ExitStatus() - 128)
+	}
+	cpu := fmt.Sprintf("CPU time limit (%v) exceeded", cfg.CPUTime)
+	switch {
+	case sig == syscall.SIGXCPU:
+		return cpu
+	case sig == syscall.SIGKILL:
+		// hard limit CPU juga SIGKILL, tapi biasanya dari OOM killer
+		if cfg.CPUTime > 0 && ps.UserTime()+ps.SystemTime() >= cfg.CPUTime {
+			return cpu
+		}
+		if cfg.AddressSpace > 0 {
+			return fmt.Sprintf("killed, likely out of memory (address space limit %s)", byteSize(cfg.AddressSpace))
+		}
+		return "killed, likely out of memory"
+	case sig == syscall.SIGXFSZ:
+		return fmt.Sprintf("output file size limit (%s) exceeded", byteSize(cfg.FileSize))
+	case (sig == syscall.SIGSEGV || sig == syscall.SIGABRT || sig == syscall.SIGBUS) && cfg.AddressSpace > 0:
+		return fmt.Sprintf("crashed with %v, likely out of memory (address space limit %s)", sig, byteSize(cfg.AddressSpace))
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLimitExceeded(t *testing.T) {
+	cfg := SandboxConfig{CPUTime: time.Hour, AddressSpace: 4 << 30}
+	tests := []struct {
+		name   string
+		script string
+		cfg    SandboxConfig
+		want   string
+	}{
+		{"SIGXCPU", "kill -XCPU $$", cfg, "CPU time limit"},
+		{"SIGKILL below CPU limit", "kill -KILL $$", cfg, "likely out of memory (address space limit 4 GiB)"},
+		{"SIGKILL without limits", "kill -KILL $$", SandboxConfig{}, "likely out of memory"},
+		{"SIGKILL after CPU limit", "i=0; while [ $i -lt 100000 ]; do i=$((i+1)); done; kill -KILL $$", SandboxConfig{CPUTime: time.Millisecond}, "CPU time limit"},
+		{"soffice.bin killed (oosplash exit code)", "exit 137", cfg, "likely out of memory"},
+		{"SIGXFSZ", "kill -XFSZ $$", SandboxConfig{FileSize: 1 << 20}, "output file size limit (1 MiB)"},
+		{"plain failure", "exit 1", cfg, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("/bin/sh", "-c", tt.script)
+			if err := cmd.Run(); err == nil {
+				t.Fatal("command succeeded")
+			}
+			got := limitExceeded(cmd.ProcessState, tt.cfg)
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Fatalf("limitExceeded = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
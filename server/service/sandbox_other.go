@@ -0,0 +1,26 @@
+//go:build !linux
+
+package service
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Outside Linux soffice only gets its own profile, HOME and environment; the
+// wall time limit still applies.
+const namespacesSupported = false
+
+func sandboxCommand(bin string, args []string, cfg SandboxConfig, ns bool, dir string) *exec.Cmd {
+	return exec.Command(bin, args...)
+}
+
+func isNamespaceRefused(err error) bool { return false }
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+func limitExceeded(ps *os.ProcessState, cfg SandboxConfig) string { return "" }
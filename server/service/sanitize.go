@@ -0,0 +1,236 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SanitizePolicy decides what happens to active content in an input DOCX.
+type SanitizePolicy int
+
+const (
+	// SanitizeStrip removes it and reports what was removed (default).
+	SanitizeStrip SanitizePolicy = iota
+	// SanitizeReject fails the request with InvalidArgument instead.
+	SanitizeReject
+)
+
+// ParseSanitizePolicy accepts "strip" or "reject".
+func ParseSanitizePolicy(s string) (SanitizePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "strip":
+		return SanitizeStrip, nil
+	case "reject":
+		return SanitizeReject, nil
+	}
+	return 0, fmt.Errorf("unknown sanitize policy %q (want strip or reject)", s)
+}
+
+// WithSanitizePolicy sets how templates and DOCX merge sources with macros,
+// ActiveX/OLE objects or external relationships are handled.
+func WithSanitizePolicy(p SanitizePolicy) Option {
+	return func(s *DocService) { s.sanitize = p }
+}
+
+const (
+	relTypeHyperlink = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+	ctDocumentMain   = "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"
+	ctTemplateMain   = "application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"
+)
+
+var (
+	reOLEObject   = regexp.MustCompile(`(?s)<o:OLEObject\b[^>]*?(?:/>|>.*?</o:OLEObject>)`)
+	reControl     = regexp.MustCompile(`(?s)<w:control\b[^>]*?(?:/>|>.*?</w:control>)`)
+	reAttachedTpl = regexp.MustCompile(`<w:attachedTemplate\b[^>]*/>`)
+)
+
+// activePartKind classifies parts that are removed as a whole.
+func activePartKind(name string) string {
+	lower := strings.ToLower(name)
+	base := path.Base(lower)
+	switch {
+	case base == "vbaproject.bin" || base == "vbadata.xml":
+		return "vba_project"
+	case strings.HasPrefix(lower, "word/activex/"):
+		return "activex"
+	case strings.HasPrefix(lower, "word/embeddings/"):
+		return "ole_object"
+	}
+	return ""
+}
+
+// sanitizeDocx removes VBA projects, ActiveX controls, embedded OLE objects
+// and external relationships (except hyperlinks, which are never fetched)
+// from b. Elements that referenced removed relationships are dropped; an OLE
+// object keeps its preview picture. When there is nothing to remove, b is
+// returned unchanged.
+func sanitizeDocx(b []byte) ([]byte, []*docgenpb.SanitizedItem, error) {
+	pkg, err := openDocxPackage(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []*docgenpb.SanitizedItem
+	removed := map[string]bool{}
+	for _, name := range pkg.names {
+		if kind := activePartKind(name); kind != "" {
+			removed[name] = true
+			if !strings.Contains(name, "/_rels/") {
+				items = append(items, &docgenpb.SanitizedItem{Kind: kind, Part: name})
+			}
+		}
+	}
+
+	// relasi ke part yang dihapus dan relasi eksternal, per part pemilik
+	dropped := map[string][]string{}
+	var relsParts []string
+	for _, name := range pkg.names {
+		if strings.HasSuffix(name, ".rels") && !removed[name] {
+			relsParts = append(relsParts, name)
+		}
+	}
+	for _, relsName := range relsParts {
+		owner := relsOwner(relsName)
+		if removed[owner] {
+			removed[relsName] = true
+			continue
+		}
+		rs, err := pkg.rels(owner)
+		if err != nil {
+			return nil, nil, err
+		}
+		kept := rs.Rels[:0]
+		for _, r := range rs.Rels {
+			switch {
+			case strings.EqualFold(r.TargetMode, "External"):
+				if r.Type == relTypeHyperlink {
+					kept = append(kept, r)
+					continue
+				}
+				items = append(items, &docgenpb.SanitizedItem{Kind: "external_relationship", Part: owner, Target: r.Target})
+			case removed[resolveTarget(owner, r.Target)]:
+			default:
+				kept = append(kept, r)
+				continue
+			}
+			dropped[owner] = append(dropped[owner], r.ID)
+		}
+		if len(kept) != len(rs.Rels) {
+			rs.Rels = kept
+			if err := pkg.setRels(owner, rs); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(items) == 0 {
+		return b, nil, nil
+	}
+
+	owners := make([]string, 0, len(dropped))
+	for owner := range dropped {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		if data, ok := pkg.get(owner); ok {
+			pkg.set(owner, dropRelRefs(data, dropped[owner]))
+		}
+	}
+
+	for name := range removed {
+		pkg.remove(name)
+		pkg.remove(relsPartName(name))
+	}
+	ct, err := pkg.contentTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	overrides := ct.Overrides[:0]
+	for _, o := range ct.Overrides {
+		if removed[strings.TrimPrefix(o.PartName, "/")] {
+			continue
+		}
+		// tanpa VBA dokumen bukan lagi .docm
+		switch o.ContentType {
+		case "application/vnd.ms-word.document.macroEnabled.main+xml":
+			o.ContentType = ctDocumentMain
+		case "application/vnd.ms-word.template.macroEnabledTemplate.main+xml":
+			o.ContentType = ctTemplateMain
+		}
+		overrides = append(overrides, o)
+	}
+	ct.Overrides = overrides
+	if err := pkg.setContentTypes(ct); err != nil {
+		return nil, nil, err
+	}
+	out, err := pkg.bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, items, nil
+}
+
+// relsOwner is the inverse of relsPartName; "" for the package relationships.
+func relsOwner(relsName string) string {
+	dir, file := path.Split(relsName)
+	dir = strings.TrimSuffix(strings.TrimSuffix(dir, "/"), "_rels")
+	return strings.TrimPrefix(dir+strings.TrimSuffix(file, ".rels"), "/")
+}
+
+// dropRelRefs removes the OLE, ActiveX and attached-template elements that use
+// one of ids, then any remaining r:* attribute pointing at them.
+func dropRelRefs(data []byte, ids []string) []byte {
+	s := string(data)
+	uses := func(el string) bool {
+		for _, id := range ids {
+			if strings.Contains(el, `r:id="`+id+`"`) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, re := range []*regexp.Regexp{reOLEObject, reControl, reAttachedTpl} {
+		s = re.ReplaceAllStringFunc(s, func(el string) string {
+			if uses(el) {
+				return ""
+			}
+			return el
+		})
+	}
+	for _, id := range ids {
+		s = regexp.MustCompile(`\sr:[A-Za-z]+="`+regexp.QuoteMeta(id)+`"`).ReplaceAllString(s, "")
+	}
+	return []byte(s)
+}
+
+// sanitizeInput applies the sanitize policy to an input DOCX. what names the
+// input in errors and in the report, e.g. "template".
+func (s *DocService) sanitizeInput(what string, b []byte) ([]byte, []*docgenpb.SanitizedItem, error) {
+	out, items, err := sanitizeDocx(b)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "%s: %v", what, err)
+	}
+	if len(items) == 0 {
+		return b, nil, nil
+	}
+	if s.sanitize == SanitizeReject {
+		found := make([]string, len(items))
+		for i, it := range items {
+			found[i] = it.GetKind() + " " + firstNonEmpty(it.GetTarget(), it.GetPart())
+		}
+		return nil, nil, status.Errorf(codes.InvalidArgument, "%s contains active content: %s", what, strings.Join(found, ", "))
+	}
+	for _, it := range items {
+		it.Source = what
+		logger.Info("sanitized input docx", zap.String("source", what), zap.String("kind", it.GetKind()),
+			zap.String("part", it.GetPart()), zap.String("target", it.GetTarget()))
+	}
+	return out, items, nil
+}
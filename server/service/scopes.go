@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MethodScopes maps a full gRPC method name ("/docgen.DocService/GeneratePDF")
+// or every method of a service ("/docgen.KeyAdmin/*") to the scope a caller
+// needs. "" lets every authenticated caller in.
+type MethodScopes map[string]string
+
+var methodScopes atomic.Pointer[MethodScopes]
+
+// SetMethodScopes makes the auth interceptors enforce policy: methods it does
+// not cover are denied. Without a policy any authenticated caller may call
+// every method. Unknown method names are an error, so typos do not open or
+// close methods silently.
+func SetMethodScopes(policy MethodScopes) error {
+	known := map[string]bool{}
+	for _, sd := range []grpc.ServiceDesc{docgenpb.DocService_ServiceDesc, docgenpb.KeyAdmin_ServiceDesc} {
+		known["/"+sd.ServiceName+"/*"] = true
+		for _, m := range sd.Methods {
+			known["/"+sd.ServiceName+"/"+m.MethodName] = true
+		}
+		for _, s := range sd.Streams {
+			known["/"+sd.ServiceName+"/"+s.StreamName] = true
+		}
+	}
+	var unknown []string
+	for m := range policy {
+		if !known[m] {
+			unknown = append(unknown, m)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown methods in scope policy: %s", strings.Join(unknown, ", "))
+	}
+	p := make(MethodScopes, len(policy))
+	for m, s := range policy {
+		p[m] = strings.TrimSpace(s)
+	}
+	methodScopes.Store(&p)
+	return nil
+}
+
+// authorize checks the caller against the scope policy for method.
+func authorize(p *Principal, method string) error {
+	policy := methodScopes.Load()
+	if policy == nil {
+		return nil
+	}
+	scope, ok := (*policy)[method]
+	if !ok {
+		i := strings.LastIndex(method, "/")
+		scope, ok = (*policy)[method[:i+1]+"*"]
+	}
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "method %s is not allowed by the scope policy", method)
+	}
+	if scope != "" && !p.HasScope(scope) {
+		return status.Errorf(codes.PermissionDenied, "caller lacks scope %q", scope)
+	}
+	return nil
+}
+
+func requireScope(ctx context.Context, scope string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || !p.HasScope(scope) {
+		return status.Errorf(codes.PermissionDenied, "caller lacks scope %q", scope)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{[]string{"generate:pdf"}, "generate:pdf", true},
+		{[]string{"generate:pdf"}, "generate:docx", false},
+		{[]string{"generate:*"}, "generate:docx", true},
+		{[]string{"generate:*"}, "generator", false},
+		{[]string{"generate:*"}, "admin", false},
+		{[]string{"*"}, "admin", true},
+		{nil, "admin", false},
+	}
+	for _, tt := range tests {
+		p := &Principal{Scopes: tt.scopes}
+		if got := p.HasScope(tt.scope); got != tt.want {
+			t.Errorf("%v.HasScope(%q) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestSetMethodScopesRejectsUnknownMethods(t *testing.T) {
+	t.Cleanup(func() { methodScopes.Store(nil) })
+	err := SetMethodScopes(MethodScopes{
+		docgenpb.DocService_GeneratePDF_FullMethodName: "generate:pdf",
+		"/docgen.DocService/GeneratePdf":               "generate:pdf",
+		"/docgen.Other/*":                              "",
+	})
+	if err == nil || !strings.Contains(err.Error(), "/docgen.DocService/GeneratePdf") || !strings.Contains(err.Error(), "/docgen.Other/*") {
+		t.Fatalf("err = %v", err)
+	}
+	if methodScopes.Load() != nil {
+		t.Error("invalid policy was installed")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Cleanup(func() { methodScopes.Store(nil) })
+	generate := docgenpb.DocService_GeneratePDF_FullMethodName
+	placeholders := docgenpb.DocService_GetPlaceholders_FullMethodName
+	createKey := docgenpb.KeyAdmin_CreateAPIKey_FullMethodName
+	verify := docgenpb.DocService_VerifyPDF_FullMethodName
+
+	// tanpa policy semua caller yang terautentikasi boleh
+	if err := authorize(&Principal{}, createKey); err != nil {
+		t.Fatalf("no policy: %v", err)
+	}
+
+	if err := SetMethodScopes(MethodScopes{
+		generate:             " generate:pdf ",
+		placeholders:         "",
+		"/docgen.KeyAdmin/*": ScopeAdmin,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		scopes []string
+		method string
+		want   codes.Code
+	}{
+		{"exact scope", []string{"generate:pdf"}, generate, codes.OK},
+		{"prefix scope", []string{"generate:*"}, generate, codes.OK},
+		{"missing scope", []string{"convert:docx"}, generate, codes.PermissionDenied},
+		{"open method", nil, placeholders, codes.OK},
+		{"service wildcard", []string{ScopeAdmin}, createKey, codes.OK},
+		{"service wildcard without scope", []string{"generate:pdf"}, createKey, codes.PermissionDenied},
+		{"method not in policy", []string{"*"}, verify, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorize(&Principal{Scopes: tt.scopes}, tt.method)
+			if status.Code(err) != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorEnforcesScopes(t *testing.T) {
+	ks, err := OpenFileKeyStore(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetKeyStore(ks)
+	t.Cleanup(func() { SetKeyStore(nil); methodScopes.Store(nil) })
+	if err := ks.Put(&APIKey{ID: "k1", Name: "ci", Hash: HashAPIKey("dgk_ci"), Scopes: []string{"generate:pdf"}, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetMethodScopes(MethodScopes{
+		docgenpb.DocService_GeneratePDF_FullMethodName: "generate:pdf",
+		"/docgen.KeyAdmin/*":                           ScopeAdmin,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var caller *Principal
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = PrincipalFromContext(ctx)
+		return "ok", nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "dgk_ci"))
+	call := func(method string) error {
+		_, err := UnaryAuthInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call(docgenpb.DocService_GeneratePDF_FullMethodName); err != nil {
+		t.Fatalf("allowed method: %v", err)
+	}
+	if caller == nil || caller.ID != "key:k1" {
+		t.Errorf("principal = %+v", caller)
+	}
+	caller = nil
+	if err := call(docgenpb.KeyAdmin_ListAPIKeys_FullMethodName); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("admin method: %v", err)
+	}
+	if caller != nil {
+		t.Error("handler ran for a denied call")
+	}
+	if err := call("/grpc.health.v1.Health/Check"); err != nil {
+		t.Errorf("health check: %v", err)
+	}
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	sevError   = docgenpb.DiagnosticSeverity_SEVERITY_ERROR
+	sevWarning = docgenpb.DiagnosticSeverity_SEVERITY_WARNING
+	sevInfo    = docgenpb.DiagnosticSeverity_SEVERITY_INFO
+)
+
+var (
+	rePlaceholderName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+	reTextPart        = regexp.MustCompile(`^word/(document|header\d*|footer\d*|footnotes|endnotes)\.xml$`)
+	reRFonts          = regexp.MustCompile(`<w:rFonts\b[^>]*>`)
+	reFontAttr        = regexp.MustCompile(`\bw:(?:ascii|hAnsi|cs|eastAsia)="([^"]+)"`)
+	reThemeFont       = regexp.MustCompile(`<a:(?:latin|ea|cs) typeface="([^"]+)"`)
+	reIncludeField    = regexp.MustCompile(`(?i)\b(INCLUDETEXT|INCLUDEPICTURE|LINK)\b[^<]*`)
+)
+
+// ValidateTemplate checks a template without rendering it. Problems in the
+// template are reported as diagnostics, not as an RPC error; only uploads
+// that fail the intake limits (see DocxLimits) are rejected.
+func (s *DocService) ValidateTemplate(ctx context.Context, req *docgenpb.TemplateRequest) (*docgenpb.ValidateTemplateResponse, error) {
+	if len(req.GetTemplate()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "template is empty")
+	}
+	if err := s.checkDocx("template", req.GetTemplate()); err != nil {
+		return nil, err
+	}
+	l := &templateLinter{seen: map[string]bool{}, fonts: map[string]bool{}, loops: map[string][]loopTag{}}
+	l.lint(req.GetTemplate())
+
+	sort.SliceStable(l.diags, func(i, j int) bool { return l.diags[i].Severity < l.diags[j].Severity })
+	resp := &docgenpb.ValidateTemplateResponse{
+		Valid:        true,
+		Diagnostics:  l.diags,
+		Placeholders: l.placeholders,
+	}
+	for _, d := range l.diags {
+		if d.Severity == sevError {
+			resp.Valid = false
+		}
+	}
+	for f := range l.fonts {
+		resp.Fonts = append(resp.Fonts, f)
+	}
+	sort.Strings(resp.Fonts)
+	return resp, nil
+}
+
+type loopTag struct {
+	part      string
+	paragraph int
+	snippet   string
+}
+
+type templateLinter struct {
+	diags        []*docgenpb.TemplateDiagnostic
+	placeholders []string
+	seen         map[string]bool
+	fonts        map[string]bool
+	loops        map[string][]loopTag // tag {#x} yang belum ditutup, per nama
+	loopOrder    []string
+	sawLoop      bool
+}
+
+func (l *templateLinter) add(sev docgenpb.DiagnosticSeverity, code, part string, paragraph int, snippet, format string, args ...interface{}) {
+	l.diags = append(l.diags, &docgenpb.TemplateDiagnostic{
+		Severity:  sev,
+		Code:      code,
+		Message:   fmt.Sprintf(format, args...),
+		Part:      part,
+		Paragraph: int32(paragraph),
+		Snippet:   snippet,
+	})
+}
+
+func (l *templateLinter) lint(docx []byte) {
+	pkg, err := openDocxPackage(docx)
+	if err != nil {
+		l.add(sevError, "invalid_package", "", 0, "", "not a DOCX (zip) file: %v", err)
+		return
+	}
+	for _, name := range []string{"[Content_Types].xml", mainDocumentPart} {
+		if !pkg.has(name) {
+			l.add(sevError, "missing_part", name, 0, "", "required part %s is missing", name)
+		}
+	}
+
+	for _, name := range pkg.names {
+		data, _ := pkg.get(name)
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasSuffix(lower, "vbaproject.bin"):
+			l.add(sevWarning, "macros", name, 0, "", "template contains VBA macros; they are never run, but are copied into DOCX output")
+			continue
+		case strings.HasPrefix(lower, "word/activex/"):
+			if strings.HasSuffix(lower, ".xml") {
+				l.add(sevWarning, "activex", name, 0, "", "template contains ActiveX controls; they are not rendered in PDF output")
+			}
+		}
+		if !strings.HasSuffix(lower, ".xml") && !strings.HasSuffix(lower, ".rels") {
+			continue
+		}
+		if err := wellFormed(data); err != nil {
+			l.add(sevError, "malformed_xml", name, 0, "", "part is not well-formed XML: %v", err)
+			continue
+		}
+		switch {
+		case strings.HasSuffix(lower, ".rels"):
+			l.lintRels(name, data)
+		case reTextPart.MatchString(name):
+			l.lintText(name, data)
+		}
+		if strings.HasPrefix(name, "word/") {
+			l.collectFonts(data)
+		}
+	}
+	if ct, ok := pkg.get("[Content_Types].xml"); ok && bytes.Contains(bytes.ToLower(ct), []byte("macroenabled")) {
+		l.add(sevWarning, "macros", "[Content_Types].xml", 0, "", "package is declared macro-enabled (.docm/.dotm)")
+	}
+
+	for _, name := range l.loopOrder {
+		for _, t := range l.loops[name] {
+			l.add(sevError, "unclosed_loop", t.part, t.paragraph, t.snippet, "loop {#%s} has no closing {/%s}", name, name)
+		}
+	}
+	if l.sawLoop {
+		l.add(sevWarning, "loop_unsupported", "", 0, "", "loop tags ({#x} ... {/x}) are not expanded by this server; they appear in the output as written")
+	}
+	l.checkFonts()
+}
+
+func wellFormed(data []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// lintText checks placeholder syntax paragraph by paragraph. Word splits text
+// into runs freely, so the runs of a paragraph are joined first.
+func (l *templateLinter) lintText(part string, data []byte) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		stack []*strings.Builder // paragraf bisa bersarang (text box)
+		index []int
+		n     int
+		inT   bool
+		instr bool
+	)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				n++
+				stack = append(stack, &strings.Builder{})
+				index = append(index, n)
+			case "t":
+				inT = true
+			case "instrText":
+				instr = true
+			case "fldSimple":
+				for _, a := range t.Attr {
+					if a.Name.Local == "instr" {
+						l.lintField(part, n, a.Value)
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p":
+				if len(stack) > 0 {
+					l.lintParagraph(part, index[len(index)-1], stack[len(stack)-1].String())
+					stack, index = stack[:len(stack)-1], index[:len(index)-1]
+				}
+			case "t":
+				inT = false
+			case "instrText":
+				instr = false
+			}
+		case xml.CharData:
+			switch {
+			case instr:
+				l.lintField(part, n, string(t))
+			case inT && len(stack) > 0:
+				stack[len(stack)-1].Write(t)
+			}
+		}
+	}
+}
+
+// lintField flags field codes that pull content from outside the document.
+func (l *templateLinter) lintField(part string, paragraph int, instr string) {
+	if m := reIncludeField.FindString(instr); m != "" {
+		l.add(sevWarning, "external_field", part, paragraph, strings.TrimSpace(m),
+			"field %s loads content from outside the document when it is updated", strings.ToUpper(strings.Fields(m)[0]))
+	}
+}
+
+func (l *templateLinter) lintParagraph(part string, paragraph int, text string) {
+	for i := 0; i < len(text); {
+		open := strings.IndexByte(text[i:], '{')
+		if cl := strings.IndexByte(text[i:], '}'); cl >= 0 && (open < 0 || cl < open) {
+			pos := i + cl
+			l.add(sevWarning, "unmatched_brace", part, paragraph, snippet(text, pos, pos+1), "\"}\" without an opening \"{\"")
+			i = pos + 1
+			continue
+		}
+		if open < 0 {
+			return
+		}
+		start := i + open
+
+		// {{x}}: go-docx melewati placeholder bersarang, lalu generate gagal
+		// "not all placeholders were replaced"
+		if strings.HasPrefix(text[start:], "{{") {
+			if end := strings.Index(text[start+2:], "}}"); end >= 0 && !strings.ContainsAny(text[start+2:start+2+end], "{}") {
+				stop := start + 2 + end + 2
+				l.add(sevError, "nested_braces", part, paragraph, snippet(text, start, stop),
+					"%s: placeholders use single braces ({%s}); nested braces are not replaced", text[start:stop], text[start+2:stop-2])
+				l.tag(part, paragraph, text[start+2:stop-2], snippet(text, start, stop))
+				i = stop
+				continue
+			}
+		}
+
+		end := strings.IndexAny(text[start+1:], "{}")
+		if end < 0 || text[start+1+end] == '{' {
+			l.add(sevError, "unclosed_placeholder", part, paragraph, snippet(text, start, start+1), "\"{\" is not closed in the same paragraph")
+			i = start + 1
+			continue
+		}
+		stop := start + 1 + end + 1
+		l.tag(part, paragraph, text[start+1:stop-1], snippet(text, start, stop))
+		i = stop
+	}
+}
+
+// tag checks the text between braces: a placeholder name or a loop tag.
+func (l *templateLinter) tag(part string, paragraph int, name, snip string) {
+	switch {
+	case strings.TrimSpace(name) == "":
+		l.add(sevError, "empty_placeholder", part, paragraph, snip, "empty placeholder")
+		return
+	case strings.HasPrefix(name, "#"):
+		l.sawLoop = true
+		name = name[1:]
+		if _, ok := l.loops[name]; !ok {
+			l.loopOrder = append(l.loopOrder, name)
+		}
+		l.loops[name] = append(l.loops[name], loopTag{part, paragraph, snip})
+		return
+	case strings.HasPrefix(name, "/"):
+		l.sawLoop = true
+		name = name[1:]
+		if open := l.loops[name]; len(open) > 0 {
+			l.loops[name] = open[:len(open)-1]
+		} else {
+			l.add(sevError, "mismatched_loop", part, paragraph, snip, "{/%s} closes a loop that was not opened", name)
+		}
+		return
+	}
+	if !rePlaceholderName.MatchString(name) {
+		if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			l.add(sevError, "placeholder_whitespace", part, paragraph, snip, "placeholder {%s} contains spaces and is never replaced; use letters, digits and _ only", name)
+		} else {
+			l.add(sevError, "invalid_placeholder", part, paragraph, snip, "placeholder {%s} is never replaced; names may only contain letters, digits and _", name)
+		}
+		return
+	}
+	if !l.seen[name] {
+		l.seen[name] = true
+		l.placeholders = append(l.placeholders, name)
+	}
+}
+
+// snippet returns text[start:end] with up to 20 characters of context each side.
+func snippet(text string, start, end int) string {
+	const ctx = 20
+	from, to := start, end
+	for n := 0; from > 0 && n < ctx; n++ {
+		from--
+		for from > 0 && !utf8.RuneStart(text[from]) {
+			from--
+		}
+	}
+	for n := 0; to < len(text) && n < ctx; n++ {
+		to++
+		for to < len(text) && !utf8.RuneStart(text[to]) {
+			to++
+		}
+	}
+	out := text[from:to]
+	if from > 0 {
+		out = "…" + out
+	}
+	if to < len(text) {
+		out += "…"
+	}
+	return out
+}
+
+func (l *templateLinter) lintRels(name string, data []byte) {
+	rs := &relationships{}
+	if err := xml.Unmarshal(data, rs); err != nil {
+		return
+	}
+	for _, r := range rs.Rels {
+		if !strings.EqualFold(r.TargetMode, "External") {
+			continue
+		}
+		kind := r.Type[strings.LastIndexByte(r.Type, '/')+1:]
+		if kind == "hyperlink" {
+			l.add(sevInfo, "external_link", name, 0, r.Target, "hyperlink to %s", r.Target)
+			continue
+		}
+		// gambar/template/OLE eksternal bisa diambil LibreOffice saat konversi
+		l.add(sevWarning, "external_resource", name, 0, r.Target, "%s relationship %s points outside the document: %s", kind, r.ID, r.Target)
+	}
+}
+
+func (l *templateLinter) collectFonts(data []byte) {
+	for _, tag := range reRFonts.FindAll(data, -1) {
+		for _, m := range reFontAttr.FindAllSubmatch(tag, -1) {
+			l.fonts[string(m[1])] = true
+		}
+	}
+	for _, m := range reThemeFont.FindAllSubmatch(data, -1) {
+		if len(m[1]) > 0 {
+			l.fonts[string(m[1])] = true
+		}
+	}
+}
+
+func (l *templateLinter) checkFonts() {
+	if len(l.fonts) == 0 {
+		return
+	}
+	installed, err := systemFonts()
+	if err != nil {
+		l.add(sevInfo, "font_check_skipped", "", 0, "", "installed fonts could not be listed: %v", err)
+		return
+	}
+	var missing []string
+	for f := range l.fonts {
+		if !installed[strings.ToLower(f)] {
+			missing = append(missing, f)
+		}
+	}
+	sort.Strings(missing)
+	for _, f := range missing {
+		l.add(sevWarning, "missing_font", "", 0, f, "font %q is not installed on the conversion host; LibreOffice will substitute another font", f)
+	}
+}
+
+var fontList struct {
+	once  sync.Once
+	names map[string]bool
+	err   error
+}
+
+// systemFonts lists the font families fontconfig knows about, lower-cased.
+// The list is read once; restart the server after installing fonts.
+func systemFonts() (map[string]bool, error) {
+	fontList.once.Do(func() {
+		out, err := exec.Command("fc-list", ":", "family").Output()
+		if err != nil {
+			fontList.err = fmt.Errorf("fc-list: %v", err)
+			return
+		}
+		fontList.names = map[string]bool{}
+		for _, line := range strings.Split(string(out), "\n") {
+			// satu baris bisa berisi beberapa nama: "DejaVu Sans,DejaVu Sans Condensed"
+			for _, name := range strings.Split(line, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					fontList.names[strings.ToLower(name)] = true
+				}
+			}
+		}
+	})
+	return fontList.names, fontList.err
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tenant holds the settings of one tenant. A caller's tenant comes from its
+// credential: the API key, the JWT tenant claim or the client certificate
+// mapping. Callers without a tenant are global and see every tenant.
+type Tenant struct {
+	Name string
+	// Signer is the signer profile used when a request names none.
+	Signer string
+	// Watermark is applied to generate calls that do not ask for one, except
+	// PDF/A output: the stamp would break conformance, so those get none.
+	Watermark *docgenpb.Watermark
+	// FontDirs are made available to LibreOffice for this tenant only.
+	FontDirs []string
+}
+
+var tenants atomic.Pointer[map[string]*Tenant]
+
+// SetTenants registers the known tenants. Once any are registered, callers
+// whose credential names another tenant are rejected.
+func SetTenants(ts []Tenant) error {
+	m := make(map[string]*Tenant, len(ts))
+	for i := range ts {
+		t := ts[i]
+		if t.Watermark != nil {
+			if _, err := newWatermarkStyle(t.Watermark); err != nil {
+				return fmt.Errorf("tenant %s: %s", t.Name, status.Convert(err).Message())
+			}
+		}
+		m[t.Name] = &t
+	}
+	tenants.Store(&m)
+	return nil
+}
+
+func tenantSettings(name string) (*Tenant, bool) {
+	m := tenants.Load()
+	if m == nil || name == "" {
+		return nil, false
+	}
+	t, ok := (*m)[name]
+	return t, ok
+}
+
+// checkTenant rejects a principal whose tenant is not registered.
+func checkTenant(p *Principal) error {
+	m := tenants.Load()
+	if p.Tenant == "" || m == nil || len(*m) == 0 {
+		return nil
+	}
+	if _, ok := (*m)[p.Tenant]; !ok {
+		return status.Errorf(codes.PermissionDenied, "unknown tenant %q", p.Tenant)
+	}
+	return nil
+}
+
+// TenantFromContext returns the tenant of the caller, "" for global callers
+// and unauthenticated calls.
+func TenantFromContext(ctx context.Context) string {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.Tenant
+	}
+	return ""
+}
+
+// visibleTo reports whether a resource owned by tenant may be seen by the
+// caller of ctx: its own tenant's, or anything for a global caller.
+func visibleTo(ctx context.Context, tenant string) bool {
+	t := TenantFromContext(ctx)
+	return t == "" || t == tenant
+}
+
+// withTenantDefaults returns req with the tenant's default watermark when it
+// has none and is not PDF/A; req itself is not modified.
+func withTenantDefaults(ctx context.Context, req *docgenpb.GenerateRequest, format docgenpb.OutputFormat) *docgenpb.GenerateRequest {
+	t, ok := tenantSettings(TenantFromContext(ctx))
+	if !ok || t.Watermark == nil || req.GetWatermark() != nil || format == docgenpb.OutputFormat_OUTPUT_FORMAT_TXT {
+		return req
+	}
+	if req.GetPdf().GetPdfa() != docgenpb.PdfALevel_PDFA_NONE {
+		return req
+	}
+	r := proto.Clone(req).(*docgenpb.GenerateRequest)
+	r.Watermark = proto.Clone(t.Watermark).(*docgenpb.Watermark)
+	if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF {
+		r.Watermark.Pages = ""
+	}
+	return r
+}
+
+// tenantFontDirs returns the extra font directories of the caller's tenant.
+func tenantFontDirs(ctx context.Context) []string {
+	if t, ok := tenantSettings(TenantFromContext(ctx)); ok {
+		return t.FontDirs
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+)
+
+func TestWithTenantDefaults(t *testing.T) {
+	if err := SetTenants([]Tenant{{Name: "acme", Watermark: &docgenpb.Watermark{Text: "ACME", Pages: "1"}}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tenants.Store(nil) })
+	acme := withPrincipal(context.Background(), &Principal{ID: "key:a", Tenant: "acme"})
+	own := &docgenpb.Watermark{Text: "DRAFT"}
+	pdfa := &docgenpb.PdfOptions{Pdfa: docgenpb.PdfALevel_PDFA_2B}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       *docgenpb.GenerateRequest
+		format    docgenpb.OutputFormat
+		want      string // watermark text, "" = none
+		wantPages string
+	}{
+		{"default applied", acme, &docgenpb.GenerateRequest{}, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF, "ACME", "1"},
+		{"request watermark kept", acme, &docgenpb.GenerateRequest{Watermark: own}, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF, "DRAFT", ""},
+		{"pages dropped for docx", acme, &docgenpb.GenerateRequest{}, docgenpb.OutputFormat_OUTPUT_FORMAT_DOCX, "ACME", ""},
+		{"none for text", acme, &docgenpb.GenerateRequest{}, docgenpb.OutputFormat_OUTPUT_FORMAT_TXT, "", ""},
+		{"none for PDF/A", acme, &docgenpb.GenerateRequest{Pdf: pdfa}, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF, "", ""},
+		{"none for global caller", context.Background(), &docgenpb.GenerateRequest{}, docgenpb.OutputFormat_OUTPUT_FORMAT_PDF, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withTenantDefaults(tt.ctx, tt.req, tt.format)
+			if got.GetWatermark().GetText() != tt.want || got.GetWatermark().GetPages() != tt.wantPages {
+				t.Fatalf("watermark = %v, want %q pages %q", got.GetWatermark(), tt.want, tt.wantPages)
+			}
+			if got != tt.req && tt.req.GetWatermark() != nil {
+				t.Error("request with a watermark was copied")
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// TLSFiles names the server certificate and, for mutual TLS, the CA bundle
+// client certificates are verified against.
+type TLSFiles struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// ClientAuth is tls.NoClientCert, tls.VerifyClientCertIfGiven or
+	// tls.RequireAndVerifyClientCert.
+	ClientAuth tls.ClientAuthType
+}
+
+// TLSReloader serves the current certificate and client CA bundle; Reload
+// and Watch replace them without dropping existing connections.
+type TLSReloader struct {
+	files   TLSFiles
+	current atomic.Pointer[tls.Config]
+
+	mu     sync.Mutex // serialises reloads
+	stamps map[string]time.Time
+}
+
+func NewTLSReloader(files TLSFiles) (*TLSReloader, error) {
+	if files.ClientAuth != tls.NoClientCert && files.ClientCAFile == "" {
+		return nil, errors.New("tls: client certificate verification needs a client CA file")
+	}
+	r := &TLSReloader{files: files}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again; on error the previous configuration stays.
+func (r *TLSReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reload()
+}
+
+func (r *TLSReloader) reload() error {
+	stamps := r.fileStamps()
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   r.files.ClientAuth,
+	}
+	if r.files.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.files.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls: no certificates in %s", r.files.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+	}
+	r.current.Store(cfg)
+	r.stamps = stamps
+	return nil
+}
+
+func (r *TLSReloader) fileStamps() map[string]time.Time {
+	m := map[string]time.Time{}
+	for _, f := range []string{r.files.CertFile, r.files.KeyFile, r.files.ClientCAFile} {
+		if f == "" {
+			continue
+		}
+		if fi, err := os.Stat(f); err == nil {
+			m[f] = fi.ModTime()
+		}
+	}
+	return m
+}
+
+// Watch reloads when one of the files changes, checking every interval until
+// stop is closed. A failed reload (e.g. the certificate was replaced before
+// its key) is retried on the next check.
+func (r *TLSReloader) Watch(interval time.Duration, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+		r.mu.Lock()
+		changed := false
+		for f, mod := range r.fileStamps() {
+			if !mod.Equal(r.stamps[f]) {
+				changed = true
+			}
+		}
+		if changed {
+			if err := r.reload(); err != nil {
+				logger.Error("reload tls certificate failed, keeping previous", zap.Error(err))
+			} else {
+				logger.Info("tls certificate reloaded", zap.String("cert", r.files.CertFile))
+			}
+		}
+		r.mu.Unlock()
+	}
+}
+
+// ServerConfig returns a config that hands every new connection the
+// certificate and client CAs loaded last.
+func (r *TLSReloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+	}
+}
+
+// ParseClientAuth accepts "none", "optional" (verify a certificate if the
+// client sends one) and "require".
+func ParseClientAuth(s string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "optional":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return 0, fmt.Errorf("unknown client auth %q (want none, optional or require)", s)
+}
+
+// ---------- client certificate identity ----------
+
+// ClientCertIdentity grants scopes and a tenant to a client certificate.
+// Match is one of the certificate's names: "uri:spiffe://example.org/billing",
+// "dns:billing.internal", "email:ops@example.com" or "cn:billing".
+type ClientCertIdentity struct {
+	Match  string
+	Name   string
+	Tenant string
+	Scopes []string
+}
+
+var clientCertIdentities atomic.Pointer[[]ClientCertIdentity]
+
+// SetClientCertIdentities maps verified client certificates to callers. With
+// an empty list every verified certificate is a caller without scopes; with
+// a list, only certificates matching an entry are.
+func SetClientCertIdentities(ids []ClientCertIdentity) {
+	clientCertIdentities.Store(&ids)
+}
+
+// certNames lists the names of a certificate in the Match syntax, most
+// specific first.
+func certNames(c *x509.Certificate) []string {
+	var names []string
+	for _, u := range c.URIs {
+		names = append(names, "uri:"+u.String())
+	}
+	for _, d := range c.DNSNames {
+		names = append(names, "dns:"+strings.ToLower(d))
+	}
+	for _, e := range c.EmailAddresses {
+		names = append(names, "email:"+strings.ToLower(e))
+	}
+	if c.Subject.CommonName != "" {
+		names = append(names, "cn:"+c.Subject.CommonName)
+	}
+	return names
+}
+
+// certPrincipal returns the caller for a verified client certificate on the
+// connection of ctx. ok is false without one; err is set for a verified
+// certificate that is not mapped while a mapping is configured.
+func certPrincipal(ctx context.Context) (p *Principal, ok bool, err error) {
+	pr, found := peer.FromContext(ctx)
+	if !found {
+		return nil, false, nil
+	}
+	ti, isTLS := pr.AuthInfo.(credentials.TLSInfo)
+	if !isTLS || len(ti.State.VerifiedChains) == 0 || len(ti.State.VerifiedChains[0]) == 0 {
+		return nil, false, nil
+	}
+	names := certNames(ti.State.VerifiedChains[0][0])
+	if len(names) == 0 {
+		return nil, false, nil
+	}
+	var ids []ClientCertIdentity
+	if l := clientCertIdentities.Load(); l != nil {
+		ids = *l
+	}
+	if len(ids) == 0 {
+		return &Principal{ID: "cert:" + names[0], Method: "mtls", Name: names[0]}, true, nil
+	}
+	for _, n := range names {
+		for _, id := range ids {
+			if strings.EqualFold(id.Match, n) {
+				return &Principal{ID: "cert:" + n, Method: "mtls", Name: firstNonEmpty(id.Name, n), Tenant: id.Tenant, Scopes: id.Scopes}, true, nil
+			}
+		}
+	}
+	return nil, true, fmt.Errorf("client certificate %s is not mapped to a caller", names[0])
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Usage is the number of documents a client produced in the current UTC day
+// and month.
+type Usage struct {
+	Day        string `json:"day"` // "2006-01-02"
+	DayCount   int    `json:"day_count"`
+	Month      string `json:"month"` // "2006-01"
+	MonthCount int    `json:"month_count"`
+}
+
+// roll resets the counters whose period is over.
+func (u *Usage) roll(now time.Time) {
+	now = now.UTC()
+	if d := now.Format("2006-01-02"); u.Day != d {
+		u.Day, u.DayCount = d, 0
+	}
+	if m := now.Format("2006-01"); u.Month != m {
+		u.Month, u.MonthCount = m, 0
+	}
+}
+
+// release takes back one document counted at time at. A counter whose
+// period has rolled over since is left alone: the document was not counted
+// in the new period.
+func (u *Usage) release(at, now time.Time) {
+	u.roll(now)
+	at = at.UTC()
+	if u.Day == at.Format("2006-01-02") && u.DayCount > 0 {
+		u.DayCount--
+	}
+	if u.Month == at.Format("2006-01") && u.MonthCount > 0 {
+		u.MonthCount--
+	}
+}
+
+// UsageStore counts documents per client for the quotas.
+type UsageStore interface {
+	// Add adds n to both counters of key and returns them.
+	Add(key string, n int, now time.Time) (Usage, error)
+	// Release gives back one document that Add counted at time at.
+	Release(key string, at, now time.Time) (Usage, error)
+	Get(key string, now time.Time) Usage
+}
+
+// ---------- in-memory ----------
+
+// MemoryUsageStore keeps counters for the life of the process.
+type MemoryUsageStore struct {
+	mu    sync.Mutex
+	usage map[string]*Usage
+}
+
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{usage: map[string]*Usage{}}
+}
+
+func (s *MemoryUsageStore) Add(key string, n int, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(key, n, now), nil
+}
+
+func (s *MemoryUsageStore) Release(key string, at, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.entry(key)
+	u.release(at, now)
+	return *u, nil
+}
+
+func (s *MemoryUsageStore) entry(key string) *Usage {
+	u := s.usage[key]
+	if u == nil {
+		u = &Usage{}
+		s.usage[key] = u
+	}
+	return u
+}
+
+func (s *MemoryUsageStore) add(key string, n int, now time.Time) Usage {
+	u := s.entry(key)
+	u.roll(now)
+	u.DayCount += n
+	u.MonthCount += n
+	return *u
+}
+
+func (s *MemoryUsageStore) Get(key string, now time.Time) Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var u Usage
+	if p := s.usage[key]; p != nil {
+		u = *p
+	}
+	u.roll(now)
+	return u
+}
+
+// ---------- file ----------
+
+// FileUsageStore is a MemoryUsageStore written to a JSON file (an object of
+// Usage by client) after every change, so quotas survive restarts.
+type FileUsageStore struct {
+	MemoryUsageStore
+	path string
+}
+
+// OpenFileUsageStore loads path; a missing file starts all counters at zero.
+func OpenFileUsageStore(path string) (*FileUsageStore, error) {
+	s := &FileUsageStore{MemoryUsageStore: *NewMemoryUsageStore(), path: path}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &s.usage); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *FileUsageStore) Add(key string, n int, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.add(key, n, now)
+	if err := s.write(); err != nil {
+		s.add(key, -n, now)
+		return u, err
+	}
+	return u, nil
+}
+
+func (s *FileUsageStore) Release(key string, at, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.entry(key)
+	old := *u
+	u.release(at, now)
+	if err := s.write(); err != nil {
+		*u = old
+		return old, err
+	}
+	return *u, nil
+}
+
+// write replaces the file with the current counters (temp file + rename).
+func (s *FileUsageStore) write() error {
+	data, err := json.Marshal(s.usage)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	return err
+}
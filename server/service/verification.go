@@ -0,0 +1,232 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/skip2/go-qrcode"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Crockford base32: tanpa I, L, O, U supaya tidak tertukar saat diketik ulang
+const codeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// codeLen is the number of code characters (5 bits each), shown in groups of 4.
+const codeLen = 12
+
+// WithVerifyURL enables verification stamps. url is the public verification
+// page; "{code}" in it is replaced by the document code, otherwise the code
+// is appended.
+func WithVerifyURL(url string) Option {
+	return func(s *DocService) { s.verifyURL = url }
+}
+
+func (s *DocService) documentURL(code string) string {
+	if strings.Contains(s.verifyURL, "{code}") {
+		return strings.ReplaceAll(s.verifyURL, "{code}", code)
+	}
+	return s.verifyURL + code
+}
+
+// newDocumentCode returns a random code like "7KQ2-M9XD-4RTA" (60 bits).
+func newDocumentCode() string {
+	b := make([]byte, codeLen)
+	_, _ = rand.Read(b)
+	var sb strings.Builder
+	for i, c := range b {
+		if i > 0 && i%4 == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(codeAlphabet[c&31])
+	}
+	return sb.String()
+}
+
+// normalizeDocumentCode accepts a code as typed by a person: any case, with
+// or without separators, O for 0 and I/L for 1.
+func normalizeDocumentCode(code string) (string, bool) {
+	var raw []byte
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r == '-' || r == ' ':
+			continue
+		case r == 'O':
+			r = '0'
+		case r == 'I' || r == 'L':
+			r = '1'
+		}
+		if !strings.ContainsRune(codeAlphabet, r) {
+			return "", false
+		}
+		raw = append(raw, byte(r))
+	}
+	if len(raw) != codeLen {
+		return "", false
+	}
+	return string(raw[0:4]) + "-" + string(raw[4:8]) + "-" + string(raw[8:12]), true
+}
+
+// verificationStyle is a VerificationStamp with defaults applied.
+type verificationStyle struct {
+	position string
+	allPages bool
+	size     float64
+	margin   float64
+	label    string
+}
+
+func newVerificationStyle(v *docgenpb.VerificationStamp) (*verificationStyle, error) {
+	st := &verificationStyle{
+		position: v.GetPosition(),
+		allPages: v.GetAllPages(),
+		size:     v.GetSize(),
+		margin:   v.GetMargin(),
+		label:    v.GetLabel(),
+	}
+	switch st.position {
+	case "":
+		st.position = "br"
+	case "br", "bl", "tr", "tl":
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "verification position must be br, bl, tr or tl, got %q", st.position)
+	}
+	if st.size == 0 {
+		st.size = 56
+	} else if st.size < 16 || st.size > 300 {
+		return nil, status.Errorf(codes.InvalidArgument, "verification size must be 16-300 pt, got %g", st.size)
+	}
+	if st.margin == 0 {
+		st.margin = 24
+	} else if st.margin < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "verification margin must be >= 0, got %g", st.margin)
+	}
+	if st.label == "" {
+		st.label = "Kode verifikasi: {code}"
+	}
+	return st, nil
+}
+
+// checkVerification validates the verification option of a generate request.
+func (s *DocService) checkVerification(req *docgenpb.GenerateRequest, format docgenpb.OutputFormat) error {
+	v := req.GetVerification()
+	if v == nil {
+		return nil
+	}
+	if format != docgenpb.OutputFormat_OUTPUT_FORMAT_PDF {
+		return status.Errorf(codes.Unimplemented, "verification stamp is not supported for %v", format)
+	}
+	if s.verifyURL == "" {
+		return status.Error(codes.FailedPrecondition, "verification url is not configured on this server")
+	}
+	if s.registry == nil {
+		return status.Error(codes.FailedPrecondition, "document registry is not configured on this server")
+	}
+	if req.GetPdf().GetPdfa() != docgenpb.PdfALevel_PDFA_NONE {
+		// font teks stamp (Helvetica) tidak di-embed
+		return status.Error(codes.Unimplemented, "verification stamp is not supported with PDF/A")
+	}
+	_, err := newVerificationStyle(v)
+	return err
+}
+
+// stampVerification puts a QR code linking to url and the label (with code)
+// next to it in a corner of the last page, or of every page.
+func stampVerification(pdf []byte, v *docgenpb.VerificationStamp, code, url string) ([]byte, error) {
+	st, err := newVerificationStyle(v)
+	if err != nil {
+		return nil, err
+	}
+	var pages []string
+	if !st.allPages {
+		n, err := api.PageCount(bytes.NewReader(pdf), nil)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "page count: %v", err)
+		}
+		pages = []string{strconv.Itoa(n)}
+	}
+
+	const qrPixels = 256
+	png, err := qrcode.Encode(url, qrcode.Medium, qrPixels)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "qr code: %v", err)
+	}
+	// offset dari sudut: x ke dalam halaman, y ke dalam halaman
+	dx, dy := st.margin, st.margin
+	if st.position[1] == 'r' {
+		dx = -dx
+	}
+	if st.position[0] == 't' {
+		dy = -dy
+	}
+	desc := fmt.Sprintf("position:%s, offset:%s %s, scalefactor:%s abs, rotation:0, opacity:1",
+		st.position, pdfNum(dx), pdfNum(dy), pdfNum(st.size/qrPixels))
+	// link klik hanya untuk https; pdfcpu memisah parameter dengan "," dan ":" dan
+	// menambahkan "https://" sendiri
+	if rest, ok := strings.CutPrefix(url, "https://"); ok && !strings.ContainsAny(rest, ",: ") {
+		desc += ", url:" + rest
+	}
+	qr, err := api.ImageWatermarkForReader(bytes.NewReader(png), desc, true, false, types.POINTS)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "qr stamp: %v", err)
+	}
+
+	// teks di samping QR, rata ke arah QR
+	gap := st.size + 6
+	if dx < 0 {
+		gap = -gap
+	}
+	align := "l"
+	if dx < 0 {
+		align = "r"
+	}
+	label := strings.ReplaceAll(st.label, "{code}", code)
+	text, err := api.TextWatermark(label, fmt.Sprintf("fontname:Helvetica, points:8, scalefactor:1 abs, rotation:0, opacity:1, fillcolor:#000000, aligntext:%s, position:%s, offset:%s %s",
+		align, st.position, pdfNum(dx+gap), pdfNum(dy)), true, false, types.POINTS)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "verification label: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := api.AddWatermarks(bytes.NewReader(pdf), &out, pages, qr, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "stamp qr: %v", err)
+	}
+	pdf = out.Bytes()
+	out = bytes.Buffer{}
+	if err := api.AddWatermarks(bytes.NewReader(pdf), &out, pages, text, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "stamp verification label: %v", err)
+	}
+	return out.Bytes(), nil
+}
+
+func (s *DocService) LookupDocument(ctx context.Context, req *docgenpb.LookupDocumentRequest) (*docgenpb.DocumentInfo, error) {
+	if s.registry == nil {
+		return nil, status.Error(codes.FailedPrecondition, "document registry is not configured on this server")
+	}
+	code, ok := normalizeDocumentCode(req.GetCode())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid document code %q", req.GetCode())
+	}
+	rec, ok := s.registry.LookupCode(code)
+	if !ok || !visibleTo(ctx, rec.Tenant) {
+		return nil, status.Errorf(codes.NotFound, "document %s not found", code)
+	}
+	return &docgenpb.DocumentInfo{
+		Code:          rec.Code,
+		Sha256:        rec.SHA256,
+		Size:          int64(rec.Size),
+		ContentType:   rec.ContentType,
+		Filename:      rec.Filename,
+		SignerProfile: rec.Signer,
+		CreatedAt:     rec.CreatedAt.Format(time.RFC3339),
+		VerifyUrl:     s.documentURL(rec.Code),
+	}, nil
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoders for image.DecodeConfig
+	_ "image/png"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dedinirtadinata/docxtool/docgenpb"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	nsW = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	nsR = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	nsV = "urn:schemas-microsoft-com:vml"
+	nsO = "urn:schemas-microsoft-com:office:office"
+)
+
+var (
+	reHexColor  = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	reSectPr    = regexp.MustCompile(`<w:sectPr[\s/>]`)
+	reHeaderRef = regexp.MustCompile(`<w:headerReference[^>]*w:type="(\w+)"`)
+)
+
+// watermarkStyle is a Watermark with defaults applied.
+type watermarkStyle struct {
+	text     string
+	image    []byte
+	fontSize float64
+	color    string
+	opacity  float64
+	rotation float64
+}
+
+func newWatermarkStyle(wm *docgenpb.Watermark) (*watermarkStyle, error) {
+	st := &watermarkStyle{
+		text:     wm.GetText(),
+		image:    wm.GetImage(),
+		fontSize: wm.GetFontSize(),
+		color:    wm.GetColor(),
+		opacity:  wm.GetOpacity(),
+		rotation: 45,
+	}
+	if st.text == "" && len(st.image) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "watermark needs text or image")
+	}
+	if st.text == "" {
+		switch http.DetectContentType(st.image) {
+		case "image/png", "image/jpeg":
+		default:
+			return nil, status.Error(codes.InvalidArgument, "watermark image must be PNG or JPEG")
+		}
+	}
+	if st.color == "" {
+		st.color = "#808080"
+	} else if !reHexColor.MatchString(st.color) {
+		return nil, status.Errorf(codes.InvalidArgument, "watermark color must be #RRGGBB, got %q", st.color)
+	}
+	if st.opacity == 0 {
+		st.opacity = 0.3
+	} else if st.opacity < 0 || st.opacity > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "watermark opacity must be 0-1, got %g", st.opacity)
+	}
+	if wm.Rotation != nil {
+		st.rotation = wm.GetRotation()
+	}
+	if st.rotation < -180 || st.rotation > 180 {
+		return nil, status.Errorf(codes.InvalidArgument, "watermark rotation must be -180..180, got %g", st.rotation)
+	}
+	if st.fontSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "watermark font_size must be >= 0, got %g", st.fontSize)
+	}
+	return st, nil
+}
+
+// ---------- PDF stamping ----------
+
+// watermarkPDF stamps wm behind the content of the selected pages.
+func watermarkPDF(pdf []byte, wm *docgenpb.Watermark) ([]byte, error) {
+	st, err := newWatermarkStyle(wm)
+	if err != nil {
+		return nil, err
+	}
+	var pages []string
+	if wm.GetPages() != "" {
+		if pages, err = api.ParsePageSelection(wm.GetPages()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "watermark pages: %v", err)
+		}
+	}
+
+	desc := fmt.Sprintf("rotation:%g, opacity:%g", st.rotation, st.opacity)
+	var w *model.Watermark
+	if st.text != "" {
+		desc += ", fontname:Helvetica, fillcolor:" + st.color
+		if st.fontSize > 0 {
+			desc += fmt.Sprintf(", points:%g, scalefactor:1 abs", st.fontSize)
+		}
+		w, err = api.TextWatermark(st.text, desc, false, false, types.POINTS)
+	} else {
+		w, err = api.ImageWatermarkForReader(bytes.NewReader(st.image), desc, false, false, types.POINTS)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "watermark: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := api.AddWatermarks(bytes.NewReader(pdf), &out, pages, w, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "stamp watermark: %v", err)
+	}
+	return out.Bytes(), nil
+}
+
+// ---------- DOCX header layer ----------
+
+// watermarkDocx adds wm as a VML shape behind the text in every header of the
+// document (the way Word stores its own watermarks), creating a header for the
+// first section when it has none. Later sections without their own header
+// inherit it. Page selection is not possible at this layer.
+func watermarkDocx(docxBytes []byte, wm *docgenpb.Watermark) ([]byte, error) {
+	if wm.GetPages() != "" {
+		return nil, status.Error(codes.Unimplemented, "watermark pages are only supported for PDF output")
+	}
+	st, err := newWatermarkStyle(wm)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := openDocxPackage(docxBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "open docx: %v", err)
+	}
+	ct, err := pkg.contentTypes()
+	if err != nil {
+		return nil, err
+	}
+	docRels, err := pkg.rels(mainDocumentPart)
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	stampHeader := func(part string, content []byte) ([]byte, error) {
+		n++
+		shape, err := st.vmlShape(pkg, ct, part, n)
+		if err != nil {
+			return nil, err
+		}
+		content = ensureNamespaces(content, map[string]string{"w": nsW, "r": nsR, "v": nsV, "o": nsO})
+		return insertAfterRootStart(content, `<w:p><w:r><w:pict>`+shape+`</w:pict></w:r></w:p>`), nil
+	}
+
+	// 1) existing headers
+	for _, r := range docRels.Rels {
+		if r.Type != relTypeHeader || r.TargetMode == "External" {
+			continue
+		}
+		part := resolveTarget(mainDocumentPart, r.Target)
+		content, ok := pkg.get(part)
+		if !ok {
+			continue
+		}
+		content, err := stampHeader(part, content)
+		if err != nil {
+			return nil, err
+		}
+		pkg.set(part, content)
+	}
+
+	// 2) first section without a default (or, with a title page, first) header
+	doc, ok := pkg.get(mainDocumentPart)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s not found", mainDocumentPart)
+	}
+	body := string(doc)
+	if loc := reSectPr.FindStringIndex(body); loc != nil {
+		start := loc[0]
+		tagEnd := start + strings.IndexByte(body[start:], '>')
+		selfClosing := body[tagEnd-1] == '/'
+		sect := body[start : tagEnd+1]
+		if end := strings.Index(body[tagEnd:], "</w:sectPr>"); !selfClosing && end >= 0 {
+			sect = body[start : tagEnd+end]
+		}
+		have := map[string]bool{}
+		for _, m := range reHeaderRef.FindAllStringSubmatch(sect, -1) {
+			have[m[1]] = true
+		}
+
+		var refs strings.Builder
+		for _, typ := range []string{"default", "first"} {
+			if have[typ] || (typ == "first" && !strings.Contains(sect, "<w:titlePg")) {
+				continue
+			}
+			part := pkg.uniqueName("word", "header", "xml")
+			content, err := stampHeader(part, []byte(xmlDeclaration+`<w:hdr/>`))
+			if err != nil {
+				return nil, err
+			}
+			pkg.set(part, content)
+			ct.addOverride(part, ctHeader)
+			id := docRels.nextID()
+			docRels.Rels = append(docRels.Rels, relationship{ID: id, Type: relTypeHeader, Target: strings.TrimPrefix(part, "word/")})
+			fmt.Fprintf(&refs, `<w:headerReference w:type="%s" r:id="%s"/>`, typ, id)
+		}
+		if refs.Len() > 0 {
+			if selfClosing {
+				body = body[:tagEnd-1] + ">" + refs.String() + "</w:sectPr>" + body[tagEnd+1:]
+			} else {
+				body = body[:tagEnd+1] + refs.String() + body[tagEnd+1:]
+			}
+			pkg.set(mainDocumentPart, []byte(body))
+			if err := pkg.setRels(mainDocumentPart, docRels); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := pkg.setContentTypes(ct); err != nil {
+		return nil, err
+	}
+	return pkg.bytes()
+}
+
+const xmlDeclaration = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// Standard Word shape types for WordArt text (136) and pictures (75).
+const (
+	vmlTextShapeType = `<v:shapetype id="_x0000_t136" coordsize="21600,21600" o:spt="136" adj="10800" path="m@7,l@8,m@5,21600l@6,21600e">` +
+		`<v:formulas><v:f eqn="sum #0 0 10800"/><v:f eqn="prod #0 2 1"/><v:f eqn="sum 21600 0 @1"/><v:f eqn="sum 0 0 @2"/>` +
+		`<v:f eqn="sum 21600 0 @3"/><v:f eqn="if @0 @3 0"/><v:f eqn="if @0 21600 @1"/><v:f eqn="if @0 0 @2"/>` +
+		`<v:f eqn="if @0 @4 21600"/><v:f eqn="mid @5 @6"/><v:f eqn="mid @8 @5"/><v:f eqn="mid @7 @8"/>` +
+		`<v:f eqn="mid @6 @7"/><v:f eqn="sum @6 0 @5"/></v:formulas>` +
+		`<v:path textpathok="t" o:connecttype="custom" o:connectlocs="@9,0;@10,10800;@11,21600;@12,10800" o:connectangles="270,180,90,0"/>` +
+		`<v:textpath on="t" fitshape="t"/><v:handles><v:h position="#0,bottomRight" xrange="6629,14971"/></v:handles>` +
+		`<o:lock v:ext="edit" text="t" shapetype="t"/></v:shapetype>`
+	vmlPictureShapeType = `<v:shapetype id="_x0000_t75" coordsize="21600,21600" o:spt="75" o:preferrelative="t" path="m@4@5l@4@11@9@11@9@5xe" filled="f" stroked="f">` +
+		`<v:stroke joinstyle="miter"/><v:formulas><v:f eqn="if lineDrawn pixelLineWidth 0"/><v:f eqn="sum @0 1 0"/>` +
+		`<v:f eqn="sum 0 0 @1"/><v:f eqn="prod @2 1 2"/><v:f eqn="prod @3 21600 pixelWidth"/><v:f eqn="prod @3 21600 pixelHeight"/>` +
+		`<v:f eqn="sum @0 0 1"/><v:f eqn="prod @6 1 2"/><v:f eqn="prod @7 21600 pixelWidth"/><v:f eqn="sum @8 21600 0"/>` +
+		`<v:f eqn="prod @7 21600 pixelHeight"/><v:f eqn="sum @10 21600 0"/></v:formulas>` +
+		`<v:path o:extrusionok="f" gradientshapeok="t" o:connecttype="rect"/><o:lock v:ext="edit" aspectratio="t"/></v:shapetype>`
+
+	// centred on the page margins, behind the text
+	vmlPosition = "position:absolute;margin-left:0;margin-top:0;width:%.1fpt;height:%.1fpt;rotation:%g;z-index:-251654144;" +
+		"mso-position-horizontal:center;mso-position-horizontal-relative:margin;" +
+		"mso-position-vertical:center;mso-position-vertical-relative:margin"
+)
+
+// vmlShape renders the watermark shape for header part; image watermarks add
+// the media part and relationship to pkg.
+func (st *watermarkStyle) vmlShape(pkg *docxPackage, ct *contentTypes, part string, n int) (string, error) {
+	// VML rotates clockwise
+	rot := math.Mod(360-st.rotation, 360)
+
+	if st.text != "" {
+		width, height := 468.0, 468.0/(0.6*float64(utf8.RuneCountInString(st.text)))
+		if st.fontSize > 0 {
+			height = st.fontSize
+			width = 0.6 * st.fontSize * float64(utf8.RuneCountInString(st.text))
+		}
+		height = math.Min(height, 200)
+		return fmt.Sprintf(`%s<v:shape id="PowerPlusWaterMarkObject%d" o:spid="_x0000_s%d" type="#_x0000_t136" style="`+vmlPosition+`" o:allowincell="f" fillcolor="%s" stroked="f">`+
+			`<v:fill opacity="%g"/><v:textpath style="font-family:&quot;Arial&quot;;font-size:1pt" string="%s"/></v:shape>`,
+			vmlTextShapeType, n, 2048+n, width, height, rot, st.color, st.opacity, xmlEscape(st.text)), nil
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(st.image))
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "watermark image: %v", err)
+	}
+	ext := map[string]string{"png": "png", "jpeg": "jpeg"}[format]
+	media := pkg.uniqueName("word/media", "watermark", ext)
+	pkg.set(media, st.image)
+	ct.ensureDefault(ext, "image/"+format)
+	id, err := pkg.addRel(part, relTypeImage, strings.TrimPrefix(media, "word/"))
+	if err != nil {
+		return "", err
+	}
+
+	// 96 dpi pixels to points, at most the usual text width
+	width := float64(cfg.Width) * 0.75
+	height := float64(cfg.Height) * 0.75
+	if width > 400 {
+		height, width = height*400/width, 400
+	}
+	washout := ""
+	if st.opacity < 1 {
+		washout = ` gain="19661f" blacklevel="22938f"`
+	}
+	return fmt.Sprintf(`%s<v:shape id="WordPictureWatermark%d" o:spid="_x0000_s%d" type="#_x0000_t75" style="`+vmlPosition+`" o:allowincell="f">`+
+		`<v:imagedata r:id="%s" o:title=""%s/></v:shape>`,
+		vmlPictureShapeType, n, 2048+n, width, height, rot, id, washout), nil
+}